@@ -0,0 +1,173 @@
+package calculate
+
+import (
+	"fmt"
+	"math"
+)
+
+func unary(op Op, x Value) (Value, error) {
+	switch op {
+	case OpSub:
+		return negate(x)
+	case OpNot:
+		return bitNot(x)
+	}
+	return x, nil
+}
+
+func negate(x Value) (Value, error) {
+	switch x.kind {
+	case KindInt:
+		return intResult(SubChecked(0, x.i))
+	case KindRational:
+		return ratResult(x.r.Neg())
+	case KindComplex:
+		return ComplexValue(-x.c), nil
+	}
+	return FloatValue(-x.f), nil
+}
+
+// binary applies op after promoting both operands to the wider of their
+// kinds: int, then rational, then float, then complex. Bitwise operators
+// take ints only.
+func (e *Evaluator) binary(op Op, x, y Value) (Value, error) {
+	switch op {
+	case OpMod:
+		_, r, err := divMod(x, y, e.DivMode)
+		return r, err
+	case OpAnd, OpOr, OpXor, OpShl, OpShr:
+		return bitwise(op, x, y)
+	case OpPow:
+		if err := e.done(); err != nil {
+			return Value{}, err
+		}
+	}
+	switch {
+	case x.kind == KindComplex || y.kind == KindComplex:
+		return complexBinary(op, x.Complex128(), y.Complex128())
+	case x.kind == KindFloat || y.kind == KindFloat:
+		return floatBinary(op, x.Float64(), y.Float64())
+	case x.kind == KindRational || y.kind == KindRational:
+		return ratBinary(op, x.rational(), y)
+	}
+	return e.intBinary(op, x.i, y.i)
+}
+
+func (e *Evaluator) intBinary(op Op, a, b int) (Value, error) {
+	switch op {
+	case OpAdd:
+		return intResult(AddChecked(a, b))
+	case OpSub:
+		return intResult(SubChecked(a, b))
+	case OpMul:
+		return intResult(MulChecked(a, b))
+	case OpDiv:
+		if b != 0 && a%b != 0 {
+			if e.Exact {
+				return ratResult(NewRational(a, b))
+			}
+			return FloatValue(float64(a) / float64(b)), nil
+		}
+		return intResult(DivChecked(a, b))
+	case OpPow:
+		if b < 0 {
+			if a == 0 {
+				return Value{}, ErrDivisionByZero
+			}
+			if e.Exact {
+				return ratResult(Rational{num: a, den: 1}.Pow(b))
+			}
+			return FloatValue(math.Pow(float64(a), float64(b))), nil
+		}
+		return intResult(ipow(a, b))
+	}
+	return Value{}, fmt.Errorf("calculate: unknown operator %v", op)
+}
+
+// floatBinary computes a op b. A negative number to a fractional power
+// has no real value and gives the principal complex one; zero to a
+// negative power divides by zero, as it does for ints and rationals.
+func floatBinary(op Op, a, b float64) (Value, error) {
+	switch op {
+	case OpAdd:
+		return FloatValue(a + b), nil
+	case OpSub:
+		return FloatValue(a - b), nil
+	case OpMul:
+		return FloatValue(a * b), nil
+	case OpDiv:
+		if b == 0 {
+			return Value{}, ErrDivisionByZero
+		}
+		return FloatValue(a / b), nil
+	case OpPow:
+		if a == 0 && b < 0 {
+			return Value{}, ErrDivisionByZero
+		}
+		if a < 0 && b != math.Trunc(b) && !math.IsInf(b, 0) {
+			return complexBinary(op, complex(a, 0), complex(b, 0))
+		}
+		return FloatValue(math.Pow(a, b)), nil
+	}
+	return Value{}, fmt.Errorf("calculate: unknown operator %v", op)
+}
+
+// ratBinary computes x op y where y is an int or a rational. Powers with a
+// fractional exponent fall back to floatBinary.
+func ratBinary(op Op, x Rational, yv Value) (Value, error) {
+	y := yv.rational()
+	switch op {
+	case OpAdd:
+		return ratResult(x.Add(y))
+	case OpSub:
+		return ratResult(x.Sub(y))
+	case OpMul:
+		return ratResult(x.Mul(y))
+	case OpDiv:
+		return ratResult(x.Div(y))
+	case OpPow:
+		if !y.IsInt() {
+			return floatBinary(op, x.Float64(), y.Float64())
+		}
+		return ratResult(x.Pow(y.num))
+	}
+	return Value{}, fmt.Errorf("calculate: unknown operator %v", op)
+}
+
+func intResult(i int, err error) (Value, error) {
+	if err != nil {
+		return Value{}, err
+	}
+	return IntValue(i), nil
+}
+
+// ratResult collapses whole rationals back to ints.
+func ratResult(r Rational, err error) (Value, error) {
+	if err != nil {
+		return Value{}, err
+	}
+	return RationalValue(r), nil
+}
+
+// ipow computes base^exp for exp >= 0 by repeated squaring.
+func ipow(base, exp int) (int, error) {
+	result := 1
+	for {
+		if exp&1 == 1 {
+			r, err := MulChecked(result, base)
+			if err != nil {
+				return 0, err
+			}
+			result = r
+		}
+		exp >>= 1
+		if exp == 0 {
+			return result, nil
+		}
+		b, err := MulChecked(base, base)
+		if err != nil {
+			return 0, err
+		}
+		base = b
+	}
+}
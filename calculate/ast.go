@@ -0,0 +1,225 @@
+package calculate
+
+import "strings"
+
+// Op is an arithmetic or bitwise operator. OpAdd and OpSub double as unary
+// plus and minus in UnaryExpr; OpNot only appears there.
+type Op int
+
+const (
+	OpAdd Op = iota
+	OpSub
+	OpMul
+	OpDiv
+	OpMod
+	OpPow
+	OpAnd
+	OpOr
+	OpXor
+	OpShl
+	OpShr
+	OpNot
+)
+
+var opSymbols = [...]string{
+	OpAdd: "+",
+	OpSub: "-",
+	OpMul: "*",
+	OpDiv: "/",
+	OpMod: "%",
+	OpPow: "^",
+	OpAnd: "&",
+	OpOr:  "|",
+	OpXor: "^",
+	OpShl: "<<",
+	OpShr: ">>",
+	OpNot: "~",
+}
+
+func (op Op) String() string {
+	return opSymbols[op]
+}
+
+func (op Op) precedence() int {
+	switch op {
+	case OpOr:
+		return precOr
+	case OpXor:
+		return precXor
+	case OpAnd:
+		return precAnd
+	case OpShl, OpShr:
+		return precShift
+	case OpAdd, OpSub:
+		return precAdditive
+	case OpMul, OpDiv, OpMod:
+		return precMultiplicative
+	}
+	return precPower
+}
+
+func (op Op) rightAssoc() bool {
+	return op == OpPow
+}
+
+const (
+	precOr = iota + 1
+	precXor
+	precAnd
+	precShift
+	precAdditive
+	precMultiplicative
+	precUnary
+	precPower
+	precPrimary
+)
+
+// Node is an element of the expression syntax tree. Pos returns the
+// 1-based column the node starts at (the operator column for operations).
+type Node interface {
+	Pos() int
+	String() string
+}
+
+type NumberLit struct {
+	Column int
+	Text   string
+	Value  Value
+}
+
+type Ident struct {
+	Column int
+	Name   string
+}
+
+type UnaryExpr struct {
+	Column int
+	Op     Op
+	X      Node
+}
+
+type BinaryExpr struct {
+	Column int
+	Op     Op
+	X, Y   Node
+}
+
+type CallExpr struct {
+	Column int
+	Name   string
+	Args   []Node
+}
+
+// AssignStmt binds Name to the value of X. It only appears at the top
+// level of a parsed line.
+type AssignStmt struct {
+	Column int
+	Name   string
+	X      Node
+}
+
+// FuncDef defines a user function, as in "f(x, y) = x^2 + y". Like
+// AssignStmt it only appears at the top level.
+type FuncDef struct {
+	Column int
+	Name   string
+	Params []string
+	Body   Node
+}
+
+func (n *NumberLit) Pos() int  { return n.Column }
+func (n *Ident) Pos() int      { return n.Column }
+func (n *UnaryExpr) Pos() int  { return n.Column }
+func (n *BinaryExpr) Pos() int { return n.Column }
+func (n *CallExpr) Pos() int   { return n.Column }
+func (n *AssignStmt) Pos() int { return n.Column }
+func (n *FuncDef) Pos() int    { return n.Column }
+
+func (n *NumberLit) String() string {
+	if n.Text != "" {
+		return n.Text
+	}
+	return n.Value.String()
+}
+
+func (n *Ident) String() string {
+	return n.Name
+}
+
+func (n *UnaryExpr) String() string {
+	return n.Op.String() + wrap(n.X, precedence(n.X) < precUnary)
+}
+
+func (n *BinaryExpr) String() string {
+	if n.Op == OpXor {
+		// Spelled as a call so the output parses the same whether or not
+		// ^ means xor.
+		return "xor(" + n.X.String() + ", " + n.Y.String() + ")"
+	}
+	p := n.Op.precedence()
+	left := precedence(n.X) < p || precedence(n.X) == p && n.Op.rightAssoc()
+	right := precedence(n.Y) < p || precedence(n.Y) == p && !n.Op.rightAssoc()
+
+	var b strings.Builder
+	b.WriteString(wrap(n.X, left))
+	if n.Op == OpPow {
+		b.WriteString(n.Op.String())
+	} else {
+		b.WriteString(" " + n.Op.String() + " ")
+	}
+	b.WriteString(wrap(n.Y, right))
+	return b.String()
+}
+
+func (n *CallExpr) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (n *AssignStmt) String() string {
+	return n.Name + " = " + n.X.String()
+}
+
+func (n *FuncDef) String() string {
+	return n.Name + "(" + strings.Join(n.Params, ", ") + ") = " + n.Body.String()
+}
+
+func precedence(n Node) int {
+	switch n := n.(type) {
+	case *BinaryExpr:
+		if n.Op == OpXor {
+			return precPrimary
+		}
+		return n.Op.precedence()
+	case *UnaryExpr:
+		return precUnary
+	case *NumberLit:
+		// Literals built by Simplify may print as "-2", "1/3" or "1+2i".
+		if c, ok := n.Value.Complex(); ok {
+			switch {
+			case real(c) != 0:
+				return precAdditive
+			case imag(c) < 0:
+				return precUnary
+			}
+			return precMultiplicative
+		}
+		if n.Value.kind == KindRational {
+			return precMultiplicative
+		}
+		if n.Value.Float64() < 0 {
+			return precUnary
+		}
+	}
+	return precPrimary
+}
+
+func wrap(n Node, parens bool) string {
+	if parens {
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
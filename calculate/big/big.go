@@ -0,0 +1,195 @@
+// Package big provides the calculate operations on arbitrary-precision
+// numbers from math/big. Integer division and negative powers return
+// *big.Rat so results stay exact; *big.Float arithmetic runs under a
+// Context that fixes precision and rounding.
+package big
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang-lessons/calculate"
+)
+
+var (
+	ErrDivisionByZero = calculate.ErrDivisionByZero
+	ErrNegative       = errors.New("negative argument")
+	// ErrNaN reports a Context operation whose IEEE result would be NaN,
+	// such as Inf-Inf or 0*Inf, which *big.Float cannot represent.
+	ErrNaN = errors.New("result is not a number")
+)
+
+func Add(x, y *big.Int) *big.Int {
+	return new(big.Int).Add(x, y)
+}
+
+func Sub(x, y *big.Int) *big.Int {
+	return new(big.Int).Sub(x, y)
+}
+
+func Multiplay(x, y *big.Int) *big.Int {
+	return new(big.Int).Mul(x, y)
+}
+
+// Div returns the exact quotient x/y.
+func Div(x, y *big.Int) (*big.Rat, error) {
+	if y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return new(big.Rat).SetFrac(x, y), nil
+}
+
+// Mod returns the remainder of truncated division, matching Go's % operator.
+func Mod(x, y *big.Int) (*big.Int, error) {
+	if y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return new(big.Int).Rem(x, y), nil
+}
+
+// Pow returns x^n. A negative n yields the exact reciprocal.
+func Pow(x *big.Int, n int64) (*big.Rat, error) {
+	return PowRat(new(big.Rat).SetInt(x), n)
+}
+
+func Factorial(n int64) (*big.Int, error) {
+	if n < 0 {
+		return nil, ErrNegative
+	}
+	return new(big.Int).MulRange(1, n), nil
+}
+
+func AddRat(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Add(x, y)
+}
+
+func SubRat(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Sub(x, y)
+}
+
+func MultiplayRat(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(x, y)
+}
+
+func DivRat(x, y *big.Rat) (*big.Rat, error) {
+	if y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return new(big.Rat).Quo(x, y), nil
+}
+
+// ModRat returns x - y*trunc(x/y), which has the sign of x like Mod.
+func ModRat(x, y *big.Rat) (*big.Rat, error) {
+	q, err := DivRat(x, y)
+	if err != nil {
+		return nil, err
+	}
+	trunc := new(big.Int).Quo(q.Num(), q.Denom())
+	r := new(big.Rat).Mul(y, new(big.Rat).SetInt(trunc))
+	return r.Sub(x, r), nil
+}
+
+func PowRat(x *big.Rat, n int64) (*big.Rat, error) {
+	if n < 0 && x.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	abs := new(big.Int).Abs(big.NewInt(n))
+	num := new(big.Int).Exp(x.Num(), abs, nil)
+	den := new(big.Int).Exp(x.Denom(), abs, nil)
+	if n < 0 {
+		num, den = den, num
+	}
+	return new(big.Rat).SetFrac(num, den), nil
+}
+
+// Context holds the precision in mantissa bits and the rounding mode used
+// for *big.Float results.
+type Context struct {
+	Prec uint
+	Mode big.RoundingMode
+}
+
+var DefaultContext = Context{Prec: 256, Mode: big.ToNearestEven}
+
+func (c Context) newFloat() *big.Float {
+	return new(big.Float).SetPrec(c.Prec).SetMode(c.Mode)
+}
+
+// Float rounds the exact rational r to the context precision.
+func (c Context) Float(r *big.Rat) *big.Float {
+	return c.newFloat().SetRat(r)
+}
+
+func (c Context) Add(x, y *big.Float) (*big.Float, error) {
+	if x.IsInf() && y.IsInf() && x.Signbit() != y.Signbit() {
+		return nil, fmt.Errorf("%w: %v + %v", ErrNaN, x, y)
+	}
+	return c.newFloat().Add(x, y), nil
+}
+
+func (c Context) Sub(x, y *big.Float) (*big.Float, error) {
+	if x.IsInf() && y.IsInf() && x.Signbit() == y.Signbit() {
+		return nil, fmt.Errorf("%w: %v - %v", ErrNaN, x, y)
+	}
+	return c.newFloat().Sub(x, y), nil
+}
+
+func (c Context) Multiplay(x, y *big.Float) (*big.Float, error) {
+	if x.IsInf() && y.Sign() == 0 || y.IsInf() && x.Sign() == 0 {
+		return nil, fmt.Errorf("%w: %v * %v", ErrNaN, x, y)
+	}
+	return c.newFloat().Mul(x, y), nil
+}
+
+func (c Context) Div(x, y *big.Float) (*big.Float, error) {
+	if y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	if x.IsInf() && y.IsInf() {
+		return nil, fmt.Errorf("%w: %v / %v", ErrNaN, x, y)
+	}
+	return c.newFloat().Quo(x, y), nil
+}
+
+// Mod is computed exactly on the rational values of x and y and rounded
+// once, so it does not lose digits when x is much larger than y.
+func (c Context) Mod(x, y *big.Float) (*big.Float, error) {
+	if x.IsInf() || y.IsInf() {
+		return nil, fmt.Errorf("%w: modulo of infinite value", ErrNaN)
+	}
+	xr, _ := x.Rat(nil)
+	yr, _ := y.Rat(nil)
+	r, err := ModRat(xr, yr)
+	if err != nil {
+		return nil, err
+	}
+	return c.Float(r), nil
+}
+
+// Pow computes x^n by repeated squaring with every step rounded to the
+// context precision.
+func (c Context) Pow(x *big.Float, n int64) (*big.Float, error) {
+	if n < 0 && x.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	result := c.newFloat().SetInt64(1)
+	base := c.newFloat().Set(x)
+	for e := abs(n); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+	}
+	if n < 0 {
+		result.Quo(c.newFloat().SetInt64(1), result)
+	}
+	return result, nil
+}
+
+func abs(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
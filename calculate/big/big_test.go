@@ -0,0 +1,124 @@
+package big
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestIntOperations(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	y := big.NewInt(10)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"add", Add(x, y).String(), "123456789012345678901234567900"},
+		{"sub", Sub(y, x).String(), "-123456789012345678901234567880"},
+		{"multiplay", Multiplay(x, y).String(), "1234567890123456789012345678900"},
+		{"factorial", must(Factorial(25)).String(), "15511210043330985984000000"},
+		{"div exact", must(Div(x, y)).String(), "12345678901234567890123456789/1"},
+		{"div rational", must(Div(big.NewInt(7), big.NewInt(-21))).String(), "-1/3"},
+		{"mod", must(Mod(big.NewInt(-7), big.NewInt(3))).String(), "-1"},
+		{"pow", must(Pow(big.NewInt(2), 100)).String(), "1267650600228229401496703205376/1"},
+		{"negative pow", must(Pow(big.NewInt(-2), -3)).String(), "-1/8"},
+		{"mod rat", must(ModRat(big.NewRat(7, 2), big.NewRat(-1, 1))).String(), "1/2"},
+		{"pow rat", must(PowRat(big.NewRat(2, 3), 3)).String(), "8/27"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != tt.expected {
+				t.Errorf("got %s; expected %s", tt.result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDivisionByZero(t *testing.T) {
+	zero := big.NewInt(0)
+	if _, err := Div(big.NewInt(1), zero); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Div error = %v; expected %v", err, ErrDivisionByZero)
+	}
+	if _, err := Mod(big.NewInt(1), zero); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Mod error = %v; expected %v", err, ErrDivisionByZero)
+	}
+	if _, err := Pow(zero, -1); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Pow error = %v; expected %v", err, ErrDivisionByZero)
+	}
+	if _, err := DefaultContext.Div(big.NewFloat(1), new(big.Float)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Context.Div error = %v; expected %v", err, ErrDivisionByZero)
+	}
+	if _, err := Factorial(-1); !errors.Is(err, ErrNegative) {
+		t.Errorf("Factorial error = %v; expected %v", err, ErrNegative)
+	}
+}
+
+func TestContextNaN(t *testing.T) {
+	c := DefaultContext
+	inf, negInf, zero := new(big.Float).SetInf(false), new(big.Float).SetInf(true), new(big.Float)
+
+	tests := []struct {
+		name string
+		op   func() (*big.Float, error)
+	}{
+		{"inf + -inf", func() (*big.Float, error) { return c.Add(inf, negInf) }},
+		{"inf - inf", func() (*big.Float, error) { return c.Sub(inf, inf) }},
+		{"0 * inf", func() (*big.Float, error) { return c.Multiplay(zero, inf) }},
+		{"inf * 0", func() (*big.Float, error) { return c.Multiplay(negInf, zero) }},
+		{"inf / inf", func() (*big.Float, error) { return c.Div(inf, negInf) }},
+		{"inf mod 2", func() (*big.Float, error) { return c.Mod(inf, big.NewFloat(2)) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.op(); !errors.Is(err, ErrNaN) {
+				t.Errorf("error = %v; expected %v", err, ErrNaN)
+			}
+		})
+	}
+
+	if r, err := c.Add(inf, inf); err != nil || !r.IsInf() {
+		t.Errorf("inf + inf = %v, %v; expected +Inf", r, err)
+	}
+}
+
+func TestContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      Context
+		result   func(c Context) *big.Float
+		expected string
+	}{
+		{"third to 20 digits", DefaultContext, func(c Context) *big.Float {
+			return must(c.Div(big.NewFloat(1), big.NewFloat(3)))
+		}, "0.33333333333333333333"},
+		{"low precision rounds", Context{Prec: 4, Mode: big.ToZero}, func(c Context) *big.Float {
+			return must(c.Add(big.NewFloat(1), big.NewFloat(0.9)))
+		}, "1.875"},
+		{"pow", DefaultContext, func(c Context) *big.Float {
+			return must(c.Pow(big.NewFloat(1.5), -2))
+		}, "0.44444444444444444444"},
+		{"mod keeps digits", DefaultContext, func(c Context) *big.Float {
+			return must(c.Mod(c.Float(big.NewRat(1e18+7, 1)), big.NewFloat(10)))
+		}, "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.result(tt.ctx).Text('g', 20)
+			if result != tt.expected {
+				t.Errorf("got %s; expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
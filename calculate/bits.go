@@ -0,0 +1,176 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+var (
+	ErrInvalidInteger = errors.New("invalid integer")
+	ErrNotInteger     = errors.New("operand is not an integer")
+	ErrNegativeShift  = errors.New("negative shift count")
+)
+
+var basePrefixes = map[int]string{2: "0b", 8: "0o", 16: "0x"}
+
+// basePrefix returns the base named by a leading 0b, 0o or 0x in s, or 0.
+func basePrefix(s string) int {
+	if len(s) < 2 || s[0] != '0' {
+		return 0
+	}
+	switch s[1] {
+	case 'b', 'B':
+		return 2
+	case 'o', 'O':
+		return 8
+	case 'x', 'X':
+		return 16
+	}
+	return 0
+}
+
+// ParseInt parses a signed integer in decimal or, with a 0b, 0o or 0x
+// prefix, in binary, octal or hexadecimal. Prefixed digits may be grouped
+// with underscores, as in 0xFFFF_FFFF.
+func ParseInt(s string) (int, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidInteger, s)
+	}
+	if basePrefix(digits) == 0 {
+		return ParseIntBase(s, 10)
+	}
+	i, err := strconv.ParseInt(s, 0, strconv.IntSize)
+	return int(i), intError(s, err)
+}
+
+// ParseIntBase parses a signed integer in base 2 to 36. Digits above 9 are
+// letters in either case; the matching prefix is accepted for bases 2, 8
+// and 16.
+func ParseIntBase(s string, base int) (int, error) {
+	if base < 2 || base > 36 {
+		return 0, fmt.Errorf("%w: base %d", ErrInvalidInteger, base)
+	}
+	digits, sign := s, ""
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits, sign = digits[1:], digits[:1]
+	}
+	if basePrefix(digits) == base {
+		digits = digits[2:]
+	}
+	i, err := strconv.ParseInt(sign+digits, base, strconv.IntSize)
+	return int(i), intError(s, err)
+}
+
+func intError(s string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, strconv.ErrRange):
+		return fmt.Errorf("%w: %q", ErrOverflow, s)
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidInteger, s)
+}
+
+// FormatInt formats x in base 2 to 36 using lower-case letters for digits
+// above 9. It panics on any other base, like strconv.FormatInt.
+func FormatInt[T Integer](x T, base int) string {
+	if isSigned[T]() {
+		return strconv.FormatInt(int64(x), base)
+	}
+	return strconv.FormatUint(uint64(x), base)
+}
+
+// FormatIntPrefix is FormatInt with a 0b, 0o or 0x prefix after the sign
+// for bases 2, 8 and 16, so that ParseInt reads the result back.
+func FormatIntPrefix[T Integer](x T, base int) string {
+	s := FormatInt(x, base)
+	prefix, ok := basePrefixes[base]
+	if !ok {
+		return s
+	}
+	if strings.HasPrefix(s, "-") {
+		return "-" + prefix + s[1:]
+	}
+	return prefix + s
+}
+
+// TwosComplement formats x as width binary digits in two's complement, so
+// TwosComplement(-5, 8) is "11111011". Values from the smallest signed to
+// the largest unsigned width-bit integer are accepted.
+func TwosComplement(x int, width int) (string, error) {
+	if width < 1 || width > 64 {
+		return "", fmt.Errorf("invalid width %d", width)
+	}
+	u := uint64(x)
+	if width < 64 {
+		if lo, hi := -int64(1)<<(width-1), int64(1)<<width-1; int64(x) < lo || int64(x) > hi {
+			return "", fmt.Errorf("%w: %d does not fit in %d bits", ErrOverflow, x, width)
+		}
+		u &= 1<<width - 1
+	}
+	s := strconv.FormatUint(u, 2)
+	return strings.Repeat("0", width-len(s)) + s, nil
+}
+
+func And[T Integer](a, b T) T    { return a & b }
+func Or[T Integer](a, b T) T     { return a | b }
+func Xor[T Integer](a, b T) T    { return a ^ b }
+func AndNot[T Integer](a, b T) T { return a &^ b }
+func Not[T Integer](x T) T       { return ^x }
+
+// Shl and Shr shift like Go's << and >>: bits shifted out are lost and
+// Shr sign-extends signed values.
+func Shl[T Integer](x T, n uint) T { return x << n }
+func Shr[T Integer](x T, n uint) T { return x >> n }
+
+// PopCount returns the number of one bits in x, counting a negative x in
+// two's complement at the width of T.
+func PopCount[T Integer](x T) int {
+	size := unsafe.Sizeof(x) * 8
+	u := uint64(x)
+	if size < 64 {
+		u &= 1<<size - 1
+	}
+	return bits.OnesCount64(u)
+}
+
+func bitNot(x Value) (Value, error) {
+	if x.kind != KindInt {
+		return Value{}, fmt.Errorf("%w: ~%v", ErrNotInteger, x)
+	}
+	return IntValue(^x.i), nil
+}
+
+// bitwise applies one of the bitwise operators, which only take ints.
+// Left shifts that lose bits report ErrOverflow.
+func bitwise(op Op, x, y Value) (Value, error) {
+	if x.kind != KindInt || y.kind != KindInt {
+		return Value{}, fmt.Errorf("%w: %v %v %v", ErrNotInteger, x, op, y)
+	}
+	a, b := x.i, y.i
+	switch op {
+	case OpAnd:
+		return IntValue(a & b), nil
+	case OpOr:
+		return IntValue(a | b), nil
+	case OpXor:
+		return IntValue(a ^ b), nil
+	case OpShl, OpShr:
+		if b < 0 {
+			return Value{}, ErrNegativeShift
+		}
+		if op == OpShr {
+			return IntValue(a >> b), nil
+		}
+		if a != 0 && (b >= strconv.IntSize || a<<b>>b != a) {
+			return Value{}, ErrOverflow
+		}
+		return IntValue(a << b), nil
+	}
+	return Value{}, fmt.Errorf("calculate: unknown operator %v", op)
+}
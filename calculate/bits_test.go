@@ -0,0 +1,190 @@
+package calculate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		input    string
+		base     int // 0 means ParseInt
+		expected int
+		err      error
+	}{
+		{"42", 0, 42, nil},
+		{"017", 0, 17, nil},
+		{"0b1011", 0, 11, nil},
+		{"-0o17", 0, -15, nil},
+		{"0xFF_FF", 0, 65535, nil},
+		{"+0X1f", 0, 31, nil},
+		{"0x", 0, 0, ErrInvalidInteger},
+		{"--1", 0, 0, ErrInvalidInteger},
+		{"0x8000000000000000", 0, 0, ErrOverflow},
+		{"zz", 36, 1295, nil},
+		{"-0x1f", 16, -31, nil},
+		{"1f", 16, 31, nil},
+		{"102", 2, 0, ErrInvalidInteger},
+		{"0b11", 16, 0xb11, nil},
+		{"1", 37, 0, ErrInvalidInteger},
+	}
+
+	for _, tt := range tests {
+		result, err := 0, error(nil)
+		if tt.base == 0 {
+			result, err = ParseInt(tt.input)
+		} else {
+			result, err = ParseIntBase(tt.input, tt.base)
+		}
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("parse %q base %d error = %v; expected %v", tt.input, tt.base, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || result != tt.expected {
+			t.Errorf("parse %q base %d = %d, %v; expected %d", tt.input, tt.base, result, err, tt.expected)
+		}
+	}
+}
+
+func TestFormatInt(t *testing.T) {
+	tests := []struct {
+		x        int
+		base     int
+		expected string
+		prefixed string
+	}{
+		{255, 16, "ff", "0xff"},
+		{-5, 2, "-101", "-0b101"},
+		{8, 8, "10", "0o10"},
+		{35, 36, "z", "z"},
+		{0, 10, "0", "0"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatInt(tt.x, tt.base); result != tt.expected {
+			t.Errorf("FormatInt(%d, %d) = %q; expected %q", tt.x, tt.base, result, tt.expected)
+		}
+		result := FormatIntPrefix(tt.x, tt.base)
+		if result != tt.prefixed {
+			t.Errorf("FormatIntPrefix(%d, %d) = %q; expected %q", tt.x, tt.base, result, tt.prefixed)
+		}
+		if back, err := ParseIntBase(result, tt.base); err != nil || back != tt.x {
+			t.Errorf("ParseIntBase(%q, %d) = %d, %v; expected %d", result, tt.base, back, err, tt.x)
+		}
+	}
+
+	if result := FormatInt(uint8(200), 16); result != "c8" {
+		t.Errorf("FormatInt(uint8(200), 16) = %q; expected %q", result, "c8")
+	}
+}
+
+func TestTwosComplement(t *testing.T) {
+	tests := []struct {
+		x        int
+		width    int
+		expected string
+		err      bool
+	}{
+		{-5, 8, "11111011", false},
+		{5, 8, "00000101", false},
+		{255, 8, "11111111", false},
+		{-128, 8, "10000000", false},
+		{-1, 4, "1111", false},
+		{-1, 64, "1111111111111111111111111111111111111111111111111111111111111111", false},
+		{256, 8, "", true},
+		{-129, 8, "", true},
+		{1, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		result, err := TwosComplement(tt.x, tt.width)
+		if (err != nil) != tt.err || result != tt.expected {
+			t.Errorf("TwosComplement(%d, %d) = %q, %v; expected %q", tt.x, tt.width, result, err, tt.expected)
+		}
+	}
+}
+
+func TestBitwise_Generic(t *testing.T) {
+	if r := And(uint8(0b1100), 0b1010); r != 0b1000 {
+		t.Errorf("And = %b; expected 1000", r)
+	}
+	if r := Or(int16(0b1100), 0b1010); r != 0b1110 {
+		t.Errorf("Or = %b; expected 1110", r)
+	}
+	if r := Xor(0b1100, 0b1010); r != 0b0110 {
+		t.Errorf("Xor = %b; expected 110", r)
+	}
+	if r := AndNot(0b1100, 0b1010); r != 0b0100 {
+		t.Errorf("AndNot = %b; expected 100", r)
+	}
+	if r := Not(uint8(0)); r != 255 {
+		t.Errorf("Not(uint8(0)) = %d; expected 255", r)
+	}
+	if r := Shl(int8(1), 7); r != -128 {
+		t.Errorf("Shl(int8(1), 7) = %d; expected -128", r)
+	}
+	if r := Shr(int8(-128), 7); r != -1 {
+		t.Errorf("Shr(int8(-128), 7) = %d; expected -1", r)
+	}
+	if r := PopCount(int8(-1)); r != 8 {
+		t.Errorf("PopCount(int8(-1)) = %d; expected 8", r)
+	}
+	if r := PopCount(uint64(1<<63 | 1)); r != 2 {
+		t.Errorf("PopCount(1<<63 | 1) = %d; expected 2", r)
+	}
+}
+
+func TestEval_Bitwise(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		caretXor bool
+		expected Value
+	}{
+		{"and", "0b1100 & 0b1010", false, IntValue(8)},
+		{"or", "0b1100 | 0b1010", false, IntValue(14)},
+		{"shift left", "1 << 10", false, IntValue(1024)},
+		{"shift right", "-16 >> 2", false, IntValue(-4)},
+		{"shift below additive", "1 << 2 + 1", false, IntValue(8)},
+		{"and above or", "1 | 6 & 3", false, IntValue(3)},
+		{"not", "~0", false, IntValue(-1)},
+		{"popcount", "popcount(0xFF)", false, IntValue(8)},
+		{"xor function", "xor(0xF0, 0xFF)", false, IntValue(15)},
+		{"caret is power", "2^3", false, IntValue(8)},
+		{"caret is xor", "2^3", true, IntValue(1)},
+		{"xor between and and or", "1 | 6 ^ 3 & 1", true, IntValue(7)},
+		{"double star", "2**3", true, IntValue(8)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEvaluator()
+			e.CaretXor = tt.caretXor
+			result, err := e.Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Eval(%q) = %v; expected %v", tt.expr, result, tt.expected)
+			}
+		})
+	}
+
+	errs := []struct {
+		expr string
+		err  error
+	}{
+		{"1.5 & 1", ErrNotInteger},
+		{"~0.5", ErrNotInteger},
+		{"1 << -1", ErrNegativeShift},
+		{"1 << 64", ErrOverflow},
+		{"0x4000000000000000 << 1", ErrOverflow},
+	}
+	for _, tt := range errs {
+		if _, err := Eval(tt.expr); !errors.Is(err, tt.err) {
+			t.Errorf("Eval(%q) error = %v; expected %v", tt.expr, err, tt.err)
+		}
+	}
+}
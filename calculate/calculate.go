@@ -1,9 +1,104 @@
 package calculate
 
-func Add(x, y int) int {
+import "errors"
+
+var ErrEmpty = errors.New("no values")
+
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Integer interface {
+	Signed | Unsigned
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+type Complex interface {
+	~complex64 | ~complex128
+}
+
+// Real is the set of ordered numeric types.
+type Real interface {
+	Integer | Float
+}
+
+type Number interface {
+	Integer | Float | Complex
+}
+
+func Add[T Number](x, y T) T {
 	return x + y
 }
 
-func Multiplay(a, b int) int {
+func Multiply[T Number](a, b T) T {
 	return a * b
 }
+
+// Multiplay is the int-only predecessor of Multiply, kept for existing callers.
+func Multiplay(a, b int) int {
+	return Multiply(a, b)
+}
+
+func Sum[T Number](xs ...T) T {
+	var sum T
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+// Product returns 1 for an empty list.
+func Product[T Number](xs ...T) T {
+	product := T(1)
+	for _, x := range xs {
+		product *= x
+	}
+	return product
+}
+
+func Min[T Real](xs ...T) (T, error) {
+	if len(xs) == 0 {
+		return 0, ErrEmpty
+	}
+	m := xs[0]
+	for _, x := range xs[1:] {
+		if x < m {
+			m = x
+		}
+	}
+	return m, nil
+}
+
+func Max[T Real](xs ...T) (T, error) {
+	if len(xs) == 0 {
+		return 0, ErrEmpty
+	}
+	m := xs[0]
+	for _, x := range xs[1:] {
+		if x > m {
+			m = x
+		}
+	}
+	return m, nil
+}
+
+// Mean returns the arithmetic mean. For integer types the division
+// truncates like any other integer division.
+func Mean[T Number](xs ...T) (T, error) {
+	if len(xs) == 0 {
+		return 0, ErrEmpty
+	}
+	// T(len(xs)) is not allowed when T may be complex, so count in T.
+	var n T
+	for range xs {
+		n += 1
+	}
+	return Sum(xs...) / n, nil
+}
@@ -25,3 +25,75 @@ func TestAdd_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiply_Generic(t *testing.T) {
+	if result := Multiply(2.5, 4.0); result != 10 {
+		t.Errorf("Multiply(2.5, 4.0) = %v; expected 10", result)
+	}
+	if result := Multiply(uint8(16), 2); result != 32 {
+		t.Errorf("Multiply(uint8(16), 2) = %v; expected 32", result)
+	}
+	if result := Multiply(1i, 1i); result != -1 {
+		t.Errorf("Multiply(1i, 1i) = %v; expected -1", result)
+	}
+	if result := Multiplay(-3, 7); result != -21 {
+		t.Errorf("Multiplay(-3, 7) = %d; expected -21", result)
+	}
+}
+
+func TestAggregates_TableDriven(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []float64
+		sum     float64
+		product float64
+		min     float64
+		max     float64
+		mean    float64
+	}{
+		{"single value", []float64{4}, 4, 4, 4, 4, 4},
+		{"celsius readings", []float64{-10, 0, 36.6}, 26.6, -0, -10, 36.6, 26.6 / 3},
+		{"mixed signs", []float64{-2, 3, -4}, -3, 24, -4, 3, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Sum(tt.input...); result != tt.sum {
+				t.Errorf("Sum(%v) = %v; expected %v", tt.input, result, tt.sum)
+			}
+			if result := Product(tt.input...); result != tt.product {
+				t.Errorf("Product(%v) = %v; expected %v", tt.input, result, tt.product)
+			}
+			if result, _ := Min(tt.input...); result != tt.min {
+				t.Errorf("Min(%v) = %v; expected %v", tt.input, result, tt.min)
+			}
+			if result, _ := Max(tt.input...); result != tt.max {
+				t.Errorf("Max(%v) = %v; expected %v", tt.input, result, tt.max)
+			}
+			if result, _ := Mean(tt.input...); result != tt.mean {
+				t.Errorf("Mean(%v) = %v; expected %v", tt.input, result, tt.mean)
+			}
+		})
+	}
+}
+
+func TestAggregates_Empty(t *testing.T) {
+	if result := Sum[int](); result != 0 {
+		t.Errorf("Sum() = %d; expected 0", result)
+	}
+	if result := Product[int](); result != 1 {
+		t.Errorf("Product() = %d; expected 1", result)
+	}
+	if _, err := Min[int](); err != ErrEmpty {
+		t.Errorf("Min() error = %v; expected %v", err, ErrEmpty)
+	}
+	if _, err := Max[float32](); err != ErrEmpty {
+		t.Errorf("Max() error = %v; expected %v", err, ErrEmpty)
+	}
+	if _, err := Mean[complex128](); err != ErrEmpty {
+		t.Errorf("Mean() error = %v; expected %v", err, ErrEmpty)
+	}
+	if result, _ := Mean(1, 2); result != 1 {
+		t.Errorf("Mean(1, 2) = %d; expected 1", result)
+	}
+}
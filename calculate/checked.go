@@ -0,0 +1,124 @@
+package calculate
+
+import (
+	"errors"
+	"unsafe"
+)
+
+var ErrOverflow = errors.New("integer overflow")
+
+func isSigned[T Integer]() bool {
+	var zero T
+	return ^zero < 0
+}
+
+// bounds returns the smallest and largest values representable by T.
+func bounds[T Integer]() (lo, hi T) {
+	var zero T
+	if !isSigned[T]() {
+		return 0, ^zero
+	}
+	bits := unsafe.Sizeof(zero) * 8
+	lo = T(1) << (bits - 1)
+	return lo, ^lo
+}
+
+func AddChecked[T Integer](a, b T) (T, error) {
+	lo, hi := bounds[T]()
+	if b > 0 && a > hi-b || b < 0 && a < lo-b {
+		return 0, ErrOverflow
+	}
+	return a + b, nil
+}
+
+func SubChecked[T Integer](a, b T) (T, error) {
+	lo, hi := bounds[T]()
+	if !isSigned[T]() {
+		if b > a {
+			return 0, ErrOverflow
+		}
+		return a - b, nil
+	}
+	if b < 0 && a > hi+b || b > 0 && a < lo+b {
+		return 0, ErrOverflow
+	}
+	return a - b, nil
+}
+
+func MulChecked[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	lo, _ := bounds[T]()
+	if isSigned[T]() && (a == lo && b == ^T(0) || b == lo && a == ^T(0)) {
+		return 0, ErrOverflow
+	}
+	c := a * b
+	if c/b != a {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// DivChecked performs truncated division. The only overflowing case is the
+// most negative value divided by -1.
+func DivChecked[T Integer](a, b T) (T, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	lo, _ := bounds[T]()
+	if isSigned[T]() && a == lo && b == ^T(0) {
+		return 0, ErrOverflow
+	}
+	return a / b, nil
+}
+
+// AddSaturating returns a + b clamped to the range of T.
+func AddSaturating[T Integer](a, b T) T {
+	c, err := AddChecked(a, b)
+	if err == nil {
+		return c
+	}
+	lo, hi := bounds[T]()
+	if b < 0 {
+		return lo
+	}
+	return hi
+}
+
+// SubSaturating returns a - b clamped to the range of T.
+func SubSaturating[T Integer](a, b T) T {
+	c, err := SubChecked(a, b)
+	if err == nil {
+		return c
+	}
+	lo, hi := bounds[T]()
+	if !isSigned[T]() || b > 0 {
+		return lo
+	}
+	return hi
+}
+
+// MulSaturating returns a * b clamped to the range of T.
+func MulSaturating[T Integer](a, b T) T {
+	c, err := MulChecked(a, b)
+	if err == nil {
+		return c
+	}
+	lo, hi := bounds[T]()
+	if (a < 0) != (b < 0) {
+		return lo
+	}
+	return hi
+}
+
+// DivSaturating returns a / b clamped to the range of T. Division by zero
+// is still reported as an error since it has no meaningful bound.
+func DivSaturating[T Integer](a, b T) (T, error) {
+	c, err := DivChecked(a, b)
+	if errors.Is(err, ErrOverflow) {
+		_, hi := bounds[T]()
+		return hi, nil
+	}
+	return c, err
+}
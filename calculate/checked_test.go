@@ -0,0 +1,98 @@
+package calculate
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestChecked_Int8(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b int8) (int8, error)
+		a        int8
+		b        int8
+		expected int8
+		err      error
+	}{
+		{"add", AddChecked[int8], 100, 27, 127, nil},
+		{"add overflow", AddChecked[int8], 100, 28, 0, ErrOverflow},
+		{"add underflow", AddChecked[int8], -100, -29, 0, ErrOverflow},
+		{"sub", SubChecked[int8], -100, 28, -128, nil},
+		{"sub overflow", SubChecked[int8], 0, -128, 0, ErrOverflow},
+		{"mul", MulChecked[int8], -16, 8, -128, nil},
+		{"mul overflow", MulChecked[int8], 16, 8, 0, ErrOverflow},
+		{"mul min by -1", MulChecked[int8], -128, -1, 0, ErrOverflow},
+		{"div", DivChecked[int8], -7, 2, -3, nil},
+		{"div min by -1", DivChecked[int8], -128, -1, 0, ErrOverflow},
+		{"div by zero", DivChecked[int8], 1, 0, 0, ErrDivisionByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.op(tt.a, tt.b)
+			if !errors.Is(err, tt.err) || result != tt.expected {
+				t.Errorf("(%d, %d) = %d, %v; expected %d, %v",
+					tt.a, tt.b, result, err, tt.expected, tt.err)
+			}
+		})
+	}
+}
+
+func TestChecked_Uint(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b uint64) (uint64, error)
+		a        uint64
+		b        uint64
+		expected uint64
+		err      error
+	}{
+		{"add", AddChecked[uint64], math.MaxUint64 - 1, 1, math.MaxUint64, nil},
+		{"add overflow", AddChecked[uint64], math.MaxUint64, 1, 0, ErrOverflow},
+		{"sub", SubChecked[uint64], 5, 5, 0, nil},
+		{"sub underflow", SubChecked[uint64], 4, 5, 0, ErrOverflow},
+		{"mul overflow", MulChecked[uint64], 1 << 32, 1 << 32, 0, ErrOverflow},
+		{"div by zero", DivChecked[uint64], 1, 0, 0, ErrDivisionByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.op(tt.a, tt.b)
+			if !errors.Is(err, tt.err) || result != tt.expected {
+				t.Errorf("(%d, %d) = %d, %v; expected %d, %v",
+					tt.a, tt.b, result, err, tt.expected, tt.err)
+			}
+		})
+	}
+}
+
+func TestSaturating(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   int64
+		expected int64
+	}{
+		{"add high", int64(AddSaturating[int16](math.MaxInt16, 1)), math.MaxInt16},
+		{"add low", int64(AddSaturating[int16](math.MinInt16, -1)), math.MinInt16},
+		{"sub high", int64(SubSaturating[int32](math.MaxInt32, -1)), math.MaxInt32},
+		{"sub low", int64(SubSaturating[int32](math.MinInt32, 1)), math.MinInt32},
+		{"sub unsigned", int64(SubSaturating[uint8](1, 2)), 0},
+		{"mul same signs", MulSaturating[int64](math.MinInt64, -2), math.MaxInt64},
+		{"mul mixed signs", MulSaturating[int64](math.MaxInt64, -2), math.MinInt64},
+		{"mul unsigned", int64(MulSaturating[uint8](16, 16)), math.MaxUint8},
+		{"in range", int64(AddSaturating(2, 3)), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != tt.expected {
+				t.Errorf("got %d; expected %d", tt.result, tt.expected)
+			}
+		})
+	}
+
+	if q, err := DivSaturating[int8](-128, -1); err != nil || q != 127 {
+		t.Errorf("DivSaturating(-128, -1) = %d, %v; expected 127, nil", q, err)
+	}
+}
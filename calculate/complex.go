@@ -0,0 +1,88 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strconv"
+)
+
+var ErrComplex = errors.New("not defined for complex numbers")
+
+// complexBinary computes a op b. Integer powers multiply exactly, so that
+// i^2 is -1 rather than -1 with a rounding error in the imaginary part.
+func complexBinary(op Op, a, b complex128) (Value, error) {
+	switch op {
+	case OpAdd:
+		return ComplexValue(a + b), nil
+	case OpSub:
+		return ComplexValue(a - b), nil
+	case OpMul:
+		return ComplexValue(a * b), nil
+	case OpDiv:
+		if b == 0 {
+			return Value{}, ErrDivisionByZero
+		}
+		return ComplexValue(a / b), nil
+	case OpPow:
+		if a == 0 && b != 0 {
+			// 0^b is 0 when Re b > 0 and has no value otherwise.
+			switch {
+			case real(b) > 0:
+				return ComplexValue(0), nil
+			case real(b) < 0:
+				return Value{}, ErrDivisionByZero
+			}
+			return Value{}, fmt.Errorf("%w: 0^%s", ErrIndeterminate, FormatComplex(b))
+		}
+		// cmplx.Pow goes through exp and log, which leaves residue such as
+		// 1.2e-16+2i for (-4)^0.5; square roots are exact.
+		switch b {
+		case 0.5:
+			return ComplexValue(cmplx.Sqrt(a)), nil
+		case -0.5:
+			return ComplexValue(1 / cmplx.Sqrt(a)), nil
+		}
+		if n := real(b); imag(b) == 0 && n == math.Trunc(n) && math.Abs(n) <= 1<<20 {
+			return ComplexValue(cpow(a, int(n))), nil
+		}
+		return ComplexValue(cmplx.Pow(a, b)), nil
+	}
+	return Value{}, fmt.Errorf("calculate: unknown operator %v", op)
+}
+
+// cpow computes z^n by repeated squaring.
+func cpow(z complex128, n int) complex128 {
+	if n < 0 {
+		return 1 / cpow(z, -n)
+	}
+	result := complex(1, 0)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result *= z
+		}
+		z *= z
+	}
+	return result
+}
+
+// FormatComplex prints c in rectangular form, such as "3-4i" or "2i", which
+// Parse reads back.
+func FormatComplex(c complex128) string {
+	im := strconv.FormatFloat(imag(c), 'g', -1, 64) + "i"
+	if real(c) == 0 {
+		return im
+	}
+	if !math.Signbit(imag(c)) {
+		im = "+" + im
+	}
+	return strconv.FormatFloat(real(c), 'g', -1, 64) + im
+}
+
+// FormatPolar prints c as its modulus and argument in radians, such as
+// "5∠0.9272952180016122".
+func FormatPolar(c complex128) string {
+	r, theta := cmplx.Polar(c)
+	return strconv.FormatFloat(r, 'g', -1, 64) + "∠" + strconv.FormatFloat(theta, 'g', -1, 64)
+}
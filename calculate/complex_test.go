@@ -0,0 +1,126 @@
+package calculate
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestEval_Complex(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected Value
+	}{
+		{"unit", "i", ComplexValue(1i)},
+		{"rectangular", "3 + 4i", ComplexValue(3 + 4i)},
+		{"square of i", "i^2", FloatValue(-1)},
+		{"product", "(1 + 2i) * (3 - i)", ComplexValue(5 + 5i)},
+		{"quotient", "(1 + i) / (1 - i)", ComplexValue(1i)},
+		{"negative power", "i^-1", ComplexValue(-1i)},
+		{"sqrt negative", "sqrt(-4)", ComplexValue(2i)},
+		{"sqrt complex", "sqrt(2i)", ComplexValue(1 + 1i)},
+		{"log negative", "log(-1)", ComplexValue(complex(0, math.Pi))},
+		{"fractional power of negative", "(-8)^(1/3)", ComplexValue(1 + complex(0, math.Sqrt(3)))},
+		{"euler", "exp(i * pi)", FloatValue(-1)},
+		{"re", "re(3 - 4i)", FloatValue(3)},
+		{"im", "im(3 - 4i)", FloatValue(-4)},
+		{"im of real", "im(7)", IntValue(0)},
+		{"abs", "abs(3 - 4i)", FloatValue(5)},
+		{"arg", "arg(1 + i)", FloatValue(math.Pi / 4)},
+		{"arg negative real", "arg(-2)", FloatValue(math.Pi)},
+		{"conj", "conj(3 - 4i)", ComplexValue(3 + 4i)},
+		{"conj real", "conj(2)", IntValue(2)},
+		{"unary minus", "-(1 + i)", ComplexValue(-1 - 1i)},
+		{"zero to a complex power", "0^(1 + i)", FloatValue(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+			if cmplx.Abs(result.Complex128()-tt.expected.Complex128()) > 1e-12 {
+				t.Errorf("Eval(%q) = %v; expected %v", tt.expr, result, tt.expected)
+			}
+			// exp(i·pi) keeps a rounding error in its imaginary part.
+			if tt.name != "euler" && result.Kind() != tt.expected.Kind() {
+				t.Errorf("Eval(%q) kind = %v; expected %v", tt.expr, result.Kind(), tt.expected.Kind())
+			}
+		})
+	}
+}
+
+func TestEval_ComplexSquareRoot(t *testing.T) {
+	// Exponent 1/2 goes through cmplx.Sqrt, so no rounding residue is left
+	// in the real part.
+	for expr, expected := range map[string]Value{
+		"(-4)^0.5":  ComplexValue(2i),
+		"(-4)^-0.5": ComplexValue(-0.5i),
+		"(-9)^0.5":  ComplexValue(3i),
+		"(2i)^0.5":  ComplexValue(1 + 1i),
+	} {
+		if v, err := Eval(expr); err != nil || v != expected {
+			t.Errorf("Eval(%q) = %v, %v; expected %v", expr, v, err, expected)
+		}
+	}
+}
+
+func TestEval_ComplexErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  error
+	}{
+		{"floor(1 + i)", ErrComplex},
+		{"max(1, i)", ErrComplex},
+		{"(2 + i) % 2", ErrComplex},
+		{"i / 0", ErrDivisionByZero},
+		{"i & 1", ErrNotInteger},
+		{"0^i", ErrIndeterminate},
+		{"0^(-1 + i)", ErrDivisionByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if _, err := Eval(tt.expr); !errors.Is(err, tt.err) {
+				t.Errorf("Eval(%q) error = %v; expected %v", tt.expr, err, tt.err)
+			}
+		})
+	}
+
+	e := NewEvaluator()
+	e.Vars["i"] = IntValue(3)
+	if v, err := e.Eval("2i"); err != nil || v != IntValue(6) {
+		t.Errorf("Eval(2i) with i = 3 = %v, %v; expected 6", v, err)
+	}
+}
+
+func TestFormatComplex(t *testing.T) {
+	tests := []struct {
+		c           complex128
+		rect, polar string
+	}{
+		{3 + 4i, "3+4i", "5∠0.9272952180016122"},
+		{1.5 - 2i, "1.5-2i", "2.5∠-0.9272952180016122"},
+		{-1i, "-1i", "1∠-1.5707963267948966"},
+		{complex(-1, 0), "-1+0i", "1∠3.141592653589793"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatComplex(tt.c); got != tt.rect {
+			t.Errorf("FormatComplex(%v) = %q; expected %q", tt.c, got, tt.rect)
+		}
+		if got := FormatPolar(tt.c); got != tt.polar {
+			t.Errorf("FormatPolar(%v) = %q; expected %q", tt.c, got, tt.polar)
+		}
+		if tt.c == complex(-1, 0) {
+			continue
+		}
+		back, err := Eval(tt.rect)
+		if err != nil || back != ComplexValue(tt.c) {
+			t.Errorf("Eval(%q) = %v, %v; expected %v", tt.rect, back, err, tt.c)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds ties to the nearest even digit (banker's rounding).
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds ties away from zero.
+	RoundHalfUp
+	// RoundDown truncates toward zero.
+	RoundDown
+)
+
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+// maxDecimalExponent bounds the exponent ParseDecimal accepts, so that
+// untrusted input such as "1e1000000000" cannot make it allocate a
+// billion-digit coefficient.
+const maxDecimalExponent = 10000
+
+// Decimal is an exact fixed-point number: coef * 10^-scale. The zero value
+// is 0 with scale 0. Decimals are immutable; every operation returns a new
+// value.
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+func NewDecimal(unscaled int64, scale int) Decimal {
+	return newScaled(big.NewInt(unscaled), scale)
+}
+
+// ParseDecimal accepts an optional sign, digits with an optional fraction
+// and an optional exponent: "1234.50", "-0.5", "1e3". Trailing zeros are
+// kept, so "1234.50" has scale 2.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+		mantissa, exp = s[:i], e
+	}
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := strings.TrimLeft(intPart, "+-")
+	if len(intPart)-len(digits) > 1 || digits+fracPart == "" ||
+		!allDigits(digits) || !allDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	coef, _ := new(big.Int).SetString(digits+fracPart, 10)
+	if strings.HasPrefix(intPart, "-") {
+		coef.Neg(coef)
+	}
+	return newScaled(coef, len(fracPart)-exp), nil
+}
+
+// newScaled returns coef * 10^-scale. A negative scale is multiplied out
+// into the coefficient, so every Decimal has a scale of at least 0.
+func newScaled(coef *big.Int, scale int) Decimal {
+	if scale < 0 {
+		return Decimal{coef: coef.Mul(coef, pow10(-scale))}
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if !isDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.unscaled()), scale: d.scale}
+}
+
+// rescale returns the coefficient of d at a scale no smaller than d.scale.
+func (d Decimal) rescale(scale int) *big.Int {
+	return new(big.Int).Mul(d.unscaled(), pow10(scale-d.scale))
+}
+
+// Add returns d + o with the larger of the two scales.
+func (d Decimal) Add(o Decimal) Decimal {
+	scale := max(d.scale, o.scale)
+	return Decimal{coef: new(big.Int).Add(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	return d.Add(o.Neg())
+}
+
+// Mul returns the exact product, whose scale is the sum of both scales.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.unscaled(), o.unscaled()), scale: d.scale + o.scale}
+}
+
+// Div returns d / o rounded to the given scale. A negative scale rounds to
+// a multiple of a power of ten, and the result has scale 0.
+func (d Decimal) Div(o Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if o.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	// d/o = (dc / oc) * 10^(o.scale - d.scale); shift so the quotient
+	// comes out at the requested scale.
+	num, den := d.unscaled(), o.unscaled()
+	if shift := scale + o.scale - d.scale; shift >= 0 {
+		num = new(big.Int).Mul(num, pow10(shift))
+	} else {
+		den = new(big.Int).Mul(den, pow10(-shift))
+	}
+	return newScaled(roundQuo(num, den, mode), scale), nil
+}
+
+// Round returns d with exactly scale fractional digits. A negative scale
+// rounds to tens, hundreds and so on: 1234.5 rounded to -2 is 1200.
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{coef: d.rescale(scale), scale: scale}
+	}
+	return newScaled(roundQuo(d.unscaled(), pow10(d.scale-scale), mode), scale)
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	scale := max(d.scale, o.scale)
+	return d.rescale(scale).Cmp(o.rescale(scale))
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.unscaled(), pow10(d.scale)).Float64()
+	return f
+}
+
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.unscaled()).String()
+	if d.scale > 0 {
+		if len(s) <= d.scale {
+			s = strings.Repeat("0", d.scale-len(s)+1) + s
+		}
+		s = s[:len(s)-d.scale] + "." + s[len(s)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON encodes d as a string so no precision is lost in clients
+// that read JSON numbers as floats.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts both "1234.50" and 1234.50. Like the standard
+// library types, it leaves d unchanged for null.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundQuo returns num/den rounded to an integer according to mode.
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 || mode == RoundDown {
+		return q
+	}
+	// Compare the discarded fraction |r/den| against one half.
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(new(big.Int).Abs(den))
+	if cmp > 0 || cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1) {
+		if num.Sign()*den.Sign() < 0 {
+			return q.Sub(q, big.NewInt(1))
+		}
+		return q.Add(q, big.NewInt(1))
+	}
+	return q
+}
@@ -0,0 +1,140 @@
+package calculate
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		scale    int
+	}{
+		{"integer", "42", "42", 0},
+		{"trailing zero kept", "1234.50", "1234.50", 2},
+		{"negative fraction", "-0.05", "-0.05", 2},
+		{"leading dot", ".5", "0.5", 1},
+		{"plus sign", "+3.0", "3.0", 1},
+		{"exponent", "1.5e3", "1500", 0},
+		{"negative exponent", "15e-3", "0.015", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDecimal(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDecimal(%q) error: %v", tt.input, err)
+			}
+			if d.String() != tt.expected || d.Scale() != tt.scale {
+				t.Errorf("ParseDecimal(%q) = %s (scale %d); expected %s (scale %d)",
+					tt.input, d, d.Scale(), tt.expected, tt.scale)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "-", "1.2.3", "abc", "--1", "1e", "1,5", "1e1000000000", "1e-1000000000"} {
+		if _, err := ParseDecimal(input); !errors.Is(err, ErrInvalidDecimal) {
+			t.Errorf("ParseDecimal(%q) error = %v; expected %v", input, err, ErrInvalidDecimal)
+		}
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	d := func(s string) Decimal {
+		v, err := ParseDecimal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		result   Decimal
+		expected string
+	}{
+		{"add aligns scales", d("1000").Add(d("200.5")), "1200.5"},
+		{"sub", d("1200.50").Sub(d("150.75")), "1049.75"},
+		{"sub below zero", d("0.1").Sub(d("0.30")), "-0.20"},
+		{"mul adds scales", d("1.5").Mul(d("-0.20")), "-0.300"},
+		{"zero value", Decimal{}.Add(d("2.5")), "2.5"},
+		{"round half even down", d("2.345").Round(2, RoundHalfEven), "2.34"},
+		{"round half even up", d("2.355").Round(2, RoundHalfEven), "2.36"},
+		{"round half up", d("2.345").Round(2, RoundHalfUp), "2.35"},
+		{"round half up negative", d("-2.345").Round(2, RoundHalfUp), "-2.35"},
+		{"round down", d("-2.349").Round(2, RoundDown), "-2.34"},
+		{"round widens", d("7").Round(2, RoundDown), "7.00"},
+		{"round to hundreds", d("1234.5").Round(-2, RoundHalfEven), "1200"},
+		{"round to tens up", d("-1235").Round(-1, RoundHalfUp), "-1240"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.result.String(); result != tt.expected {
+				t.Errorf("got %s; expected %s", result, tt.expected)
+			}
+		})
+	}
+
+	q, err := d("100").Div(d("3"), 2, RoundHalfEven)
+	if err != nil || q.String() != "33.33" {
+		t.Errorf("100 / 3 = %s, %v; expected 33.33", q, err)
+	}
+	q, err = d("-0.5").Div(d("0.04"), 0, RoundHalfEven)
+	if err != nil || q.String() != "-12" {
+		t.Errorf("-0.5 / 0.04 = %s, %v; expected -12", q, err)
+	}
+	q, err = d("12345").Div(d("2"), -2, RoundHalfEven)
+	if err != nil || q.String() != "6200" || q.Scale() != 0 || q.Float64() != 6200 {
+		t.Errorf("12345 / 2 to scale -2 = %s, %v; expected 6200", q, err)
+	}
+	if _, err := d("1").Div(Decimal{}, 2, RoundHalfUp); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("1 / 0 error = %v; expected %v", err, ErrDivisionByZero)
+	}
+}
+
+func TestDecimal_NoDrift(t *testing.T) {
+	balance := NewDecimal(0, 2)
+	cent := NewDecimal(10, 2)
+	for i := 0; i < 1000; i++ {
+		balance = balance.Add(cent)
+	}
+	for i := 0; i < 999; i++ {
+		balance = balance.Sub(cent)
+	}
+	if !balance.Equal(NewDecimal(1, 1)) {
+		t.Errorf("balance = %s; expected 0.10", balance)
+	}
+	if balance.Cmp(NewDecimal(11, 2)) >= 0 || NewDecimal(-1, 0).Cmp(balance) >= 0 {
+		t.Errorf("Cmp ordering broken for %s", balance)
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type account struct {
+		Balance Decimal `json:"balance"`
+	}
+
+	data, err := json.Marshal(account{Balance: NewDecimal(123450, 2)})
+	if err != nil || string(data) != `{"balance":"1234.50"}` {
+		t.Errorf("Marshal = %s, %v; expected {\"balance\":\"1234.50\"}", data, err)
+	}
+
+	for _, input := range []string{`{"balance":"1234.50"}`, `{"balance":1234.50}`} {
+		var acc account
+		if err := json.Unmarshal([]byte(input), &acc); err != nil {
+			t.Fatalf("Unmarshal(%s) error: %v", input, err)
+		}
+		if acc.Balance.String() != "1234.50" {
+			t.Errorf("Unmarshal(%s) = %s; expected 1234.50", input, acc.Balance)
+		}
+	}
+
+	acc := account{Balance: NewDecimal(5, 0)}
+	if err := json.Unmarshal([]byte(`{"balance":null}`), &acc); err != nil || acc.Balance.String() != "5" {
+		t.Errorf("Unmarshal(null) = %s, %v; expected the value unchanged", acc.Balance, err)
+	}
+}
@@ -0,0 +1,118 @@
+package calculate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// DivMode selects how integer division rounds and therefore which sign
+// the remainder takes.
+type DivMode int
+
+const (
+	// DivTruncated rounds toward zero like Go's / and %; the remainder has
+	// the sign of the dividend.
+	DivTruncated DivMode = iota
+	// DivFloored rounds toward negative infinity; the remainder has the
+	// sign of the divisor.
+	DivFloored
+	// DivEuclidean keeps the remainder non-negative.
+	DivEuclidean
+)
+
+func (m DivMode) String() string {
+	switch m {
+	case DivTruncated:
+		return "truncated"
+	case DivFloored:
+		return "floored"
+	case DivEuclidean:
+		return "euclidean"
+	}
+	return "DivMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// DivMod returns the quotient and remainder of a / b under mode, so that
+// a == q*b + r. It fails with ErrDivisionByZero or, for the most negative
+// value divided by -1, ErrOverflow.
+func DivMod[T Integer](a, b T, mode DivMode) (q, r T, err error) {
+	q, err = DivChecked(a, b)
+	if err != nil {
+		return 0, 0, err
+	}
+	r = a - q*b
+	if r == 0 {
+		return q, r, nil
+	}
+	switch mode {
+	case DivFloored:
+		if (r < 0) != (b < 0) {
+			q--
+			r += b
+		}
+	case DivEuclidean:
+		if r < 0 {
+			if b > 0 {
+				q--
+				r += b
+			} else {
+				q++
+				r -= b
+			}
+		}
+	}
+	return q, r, nil
+}
+
+// floatDivMod is DivMod for floats; the quotient is integral.
+func floatDivMod(a, b float64, mode DivMode) (q, r float64, err error) {
+	if b == 0 {
+		return 0, 0, ErrDivisionByZero
+	}
+	r = math.Mod(a, b)
+	switch {
+	case mode == DivFloored && r != 0 && (r < 0) != (b < 0):
+		r += b
+	case mode == DivEuclidean && r < 0:
+		r += math.Abs(b)
+	}
+	return math.Round((a - r) / b), r, nil
+}
+
+// ratDivMod is DivMod for rationals; the quotient is an integer.
+func ratDivMod(a, b Rational, mode DivMode) (q int, r Rational, err error) {
+	t, err := a.Div(b)
+	if err != nil {
+		return 0, Rational{}, err
+	}
+	q, rem, _ := DivMod(t.num, t.Den(), DivFloored)
+	switch {
+	case mode == DivTruncated && q < 0 && rem != 0,
+		mode == DivEuclidean && b.Sign() < 0 && rem != 0:
+		q++
+	}
+	qb, err := b.Mul(Rational{num: q, den: 1})
+	if err != nil {
+		return 0, Rational{}, err
+	}
+	r, err = a.Sub(qb)
+	return q, r, err
+}
+
+// divMod dispatches on the operand kinds and returns the quotient and
+// remainder as Values.
+func divMod(x, y Value, mode DivMode) (q, r Value, err error) {
+	switch {
+	case x.kind == KindComplex || y.kind == KindComplex:
+		return Value{}, Value{}, fmt.Errorf("%w: %v mod %v", ErrComplex, x, y)
+	case x.kind == KindFloat || y.kind == KindFloat:
+		fq, fr, err := floatDivMod(x.Float64(), y.Float64(), mode)
+		return FloatValue(fq), FloatValue(fr), err
+	case x.kind == KindRational || y.kind == KindRational:
+		iq, rr, err := ratDivMod(x.rational(), y.rational(), mode)
+		return IntValue(iq), RationalValue(rr), err
+	}
+	iq, ir, err := DivMod(x.i, y.i, mode)
+	return IntValue(iq), IntValue(ir), err
+}
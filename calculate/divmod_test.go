@@ -0,0 +1,97 @@
+package calculate
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDivMod_TableDriven(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b int
+		mode DivMode
+		q, r int
+	}{
+		{"truncated positive", 7, 3, DivTruncated, 2, 1},
+		{"truncated negative dividend", -7, 3, DivTruncated, -2, -1},
+		{"truncated negative divisor", 7, -3, DivTruncated, -2, 1},
+		{"truncated both negative", -7, -3, DivTruncated, 2, -1},
+		{"floored positive", 7, 3, DivFloored, 2, 1},
+		{"floored negative dividend", -7, 3, DivFloored, -3, 2},
+		{"floored negative divisor", 7, -3, DivFloored, -3, -2},
+		{"floored both negative", -7, -3, DivFloored, 2, -1},
+		{"euclidean positive", 7, 3, DivEuclidean, 2, 1},
+		{"euclidean negative dividend", -7, 3, DivEuclidean, -3, 2},
+		{"euclidean negative divisor", 7, -3, DivEuclidean, -2, 1},
+		{"euclidean both negative", -7, -3, DivEuclidean, 3, 2},
+		{"exact", -9, 3, DivFloored, -3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, r, err := DivMod(tt.a, tt.b, tt.mode)
+			if err != nil || q != tt.q || r != tt.r {
+				t.Errorf("DivMod(%d, %d, %v) = %d, %d, %v; expected %d, %d",
+					tt.a, tt.b, tt.mode, q, r, err, tt.q, tt.r)
+			}
+			if q*tt.b+r != tt.a {
+				t.Errorf("DivMod(%d, %d, %v): q*b + r = %d; expected %d",
+					tt.a, tt.b, tt.mode, q*tt.b+r, tt.a)
+			}
+		})
+	}
+}
+
+func TestDivMod_Errors(t *testing.T) {
+	if _, _, err := DivMod(5, 0, DivEuclidean); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("DivMod(5, 0) error = %v; expected %v", err, ErrDivisionByZero)
+	}
+	if _, _, err := DivMod[int8](math.MinInt8, -1, DivFloored); !errors.Is(err, ErrOverflow) {
+		t.Errorf("DivMod(MinInt8, -1) error = %v; expected %v", err, ErrOverflow)
+	}
+	if q, r, err := DivMod[uint](7, 3, DivEuclidean); err != nil || q != 2 || r != 1 {
+		t.Errorf("DivMod[uint](7, 3) = %d, %d, %v; expected 2, 1", q, r, err)
+	}
+}
+
+func TestEval_DivMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		mode     DivMode
+		expected string
+	}{
+		{"default modulo", "-7 % 3", DivTruncated, "-1"},
+		{"floored modulo", "-7 % 3", DivFloored, "2"},
+		{"euclidean modulo", "7 % -3", DivEuclidean, "1"},
+		{"div function", "div(-7, 2)", DivFloored, "-4"},
+		{"mod function", "mod(-7, 2)", DivEuclidean, "1"},
+		{"float floored", "-7.5 % 2", DivFloored, "0.5"},
+		{"float euclidean", "-7.5 % -2", DivEuclidean, "0.5"},
+		{"float div", "div(-7.5, 2)", DivTruncated, "-3"},
+		{"rational floored", "-7/2 % 1", DivFloored, "1/2"},
+		{"rational truncated div", "div(-7/2, 1)", DivTruncated, "-3"},
+		{"rational euclidean", "7/2 % -1", DivEuclidean, "1/2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEvaluator()
+			e.Exact = true
+			e.DivMode = tt.mode
+			result, err := e.Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("Eval(%q) with %v = %v; expected %v",
+					tt.expr, tt.mode, result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Eval("mod(1, 0)"); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Eval(%q) error = %v; expected %v", "mod(1, 0)", err, ErrDivisionByZero)
+	}
+}
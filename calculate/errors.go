@@ -0,0 +1,37 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrUndefined      = errors.New("undefined identifier")
+	ErrIndeterminate  = errors.New("indeterminate form")
+)
+
+// SyntaxError reports malformed input. Column is 1-based and counts runes.
+type SyntaxError struct {
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+// EvalError reports a failure while evaluating a well-formed expression,
+// such as division by zero, at the column of the offending node.
+type EvalError struct {
+	Column int
+	Err    error
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("column %d: %v", e.Column, e.Err)
+}
+
+func (e *EvalError) Unwrap() error {
+	return e.Err
+}
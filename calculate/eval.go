@@ -0,0 +1,172 @@
+package calculate
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+var constants = map[string]Value{
+	"pi": FloatValue(math.Pi),
+	"e":  FloatValue(math.E),
+	"i":  ComplexValue(1i),
+}
+
+// Evaluator evaluates expressions against a set of variables and user
+// functions. Variables shadow the built-in constants pi, e and the
+// imaginary unit i; inside a user function only its parameters and the
+// global variables are visible.
+type Evaluator struct {
+	Vars  map[string]Value
+	Funcs map[string]*Func
+
+	// MaxDepth limits nested user function calls; zero means DefaultMaxDepth.
+	MaxDepth int
+
+	// Exact makes integer division and negative integer powers produce
+	// Rational values instead of floats.
+	Exact bool
+
+	// DivMode sets the sign convention of % and of the div and mod
+	// functions. The default, DivTruncated, matches Go.
+	DivMode DivMode
+
+	// CaretXor makes ^ in Eval the bitwise xor operator; ** remains power.
+	CaretXor bool
+
+	scopes []map[string]Value
+	ctx    context.Context
+}
+
+func NewEvaluator() *Evaluator {
+	return &Evaluator{
+		Vars:  make(map[string]Value),
+		Funcs: make(map[string]*Func),
+	}
+}
+
+func (e *Evaluator) lookup(name string) (Value, bool) {
+	if len(e.scopes) > 0 {
+		if v, ok := e.scopes[len(e.scopes)-1][name]; ok {
+			return v, true
+		}
+	}
+	if v, ok := e.Vars[name]; ok {
+		return v, true
+	}
+	v, ok := constants[name]
+	return v, ok
+}
+
+// Eval parses and evaluates expr with a fresh Evaluator.
+func Eval(expr string) (Value, error) {
+	return NewEvaluator().Eval(expr)
+}
+
+// Eval parses and evaluates expr. Assignments return the assigned value;
+// function definitions return the zero Value.
+func (e *Evaluator) Eval(expr string) (Value, error) {
+	n, err := e.Parse(expr)
+	if err != nil {
+		return Value{}, err
+	}
+	return e.EvalNode(n)
+}
+
+// Parse is the package-level Parse, except that ^ means xor when
+// e.CaretXor is set.
+func (e *Evaluator) Parse(expr string) (Node, error) {
+	return parse(expr, e.CaretXor)
+}
+
+// EvalContext is Eval that gives up with ctx.Err() once ctx is done. The
+// context is checked before every user function call, since recursion can
+// run for long, and before every power. Powers also reject results too
+// large for their kind up front, so a single huge exponent fails fast.
+func (e *Evaluator) EvalContext(ctx context.Context, expr string) (Value, error) {
+	e.ctx = ctx
+	defer func() { e.ctx = nil }()
+	return e.Eval(expr)
+}
+
+// done returns the context error once the EvalContext context is done.
+func (e *Evaluator) done() error {
+	if e.ctx == nil {
+		return nil
+	}
+	return e.ctx.Err()
+}
+
+func (e *Evaluator) EvalNode(n Node) (Value, error) {
+	switch n := n.(type) {
+	case *NumberLit:
+		return n.Value, nil
+	case *Ident:
+		if v, ok := e.lookup(n.Name); ok {
+			return v, nil
+		}
+		return Value{}, &EvalError{Column: n.Column, Err: fmt.Errorf("%w %q", ErrUndefined, n.Name)}
+	case *UnaryExpr:
+		x, err := e.EvalNode(n.X)
+		if err != nil {
+			return Value{}, err
+		}
+		v, err := unary(n.Op, x)
+		if err != nil {
+			return Value{}, &EvalError{Column: n.Column, Err: err}
+		}
+		return v, nil
+	case *AssignStmt:
+		x, err := e.EvalNode(n.X)
+		if err != nil {
+			return Value{}, err
+		}
+		if e.Vars == nil {
+			e.Vars = make(map[string]Value)
+		}
+		e.Vars[n.Name] = x
+		return x, nil
+	case *FuncDef:
+		if _, ok := builtins[n.Name]; ok {
+			return Value{}, &EvalError{Column: n.Column, Err: fmt.Errorf("cannot redefine built-in function %s", n.Name)}
+		}
+		if e.Funcs == nil {
+			e.Funcs = make(map[string]*Func)
+		}
+		e.Funcs[n.Name] = &Func{Params: n.Params, Body: n.Body}
+		return Value{}, nil
+	case *CallExpr:
+		args := make([]Value, len(n.Args))
+		for i, arg := range n.Args {
+			v, err := e.EvalNode(arg)
+			if err != nil {
+				return Value{}, err
+			}
+			args[i] = v
+		}
+		v, err := e.call(n.Name, args)
+		if err != nil {
+			// Report runaway recursion once, at the outermost call.
+			if aborted(err) && len(e.scopes) > 0 {
+				return Value{}, err
+			}
+			return Value{}, &EvalError{Column: n.Column, Err: err}
+		}
+		return v, nil
+	case *BinaryExpr:
+		x, err := e.EvalNode(n.X)
+		if err != nil {
+			return Value{}, err
+		}
+		y, err := e.EvalNode(n.Y)
+		if err != nil {
+			return Value{}, err
+		}
+		v, err := e.binary(n.Op, x, y)
+		if err != nil {
+			return Value{}, &EvalError{Column: n.Column, Err: err}
+		}
+		return v, nil
+	}
+	return Value{}, fmt.Errorf("calculate: unknown node %T", n)
+}
@@ -0,0 +1,121 @@
+package calculate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEval_TableDriven(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected Value
+	}{
+		{"addition", "2 + 3", IntValue(5)},
+		{"precedence", "2 + 3 * 4", IntValue(14)},
+		{"parentheses", "(2 + 3) * 4", IntValue(20)},
+		{"unary minus", "-5 + 10", IntValue(5)},
+		{"double negation", "--3", IntValue(3)},
+		{"exact division", "12 / 4", IntValue(3)},
+		{"inexact division", "7 / 2", FloatValue(3.5)},
+		{"modulo", "-7 % 3", IntValue(-1)},
+		{"power", "2^10", IntValue(1024)},
+		{"power right associative", "2^3^2", IntValue(512)},
+		{"power binds tighter than minus", "-2^2", IntValue(-4)},
+		{"negative exponent", "2^-2", FloatValue(0.25)},
+		{"float", "1.5 * 2", FloatValue(3)},
+		{"float modulo", "7.5 % 2", FloatValue(1.5)},
+		{"exponent literal", "1e3 + 1", FloatValue(1001)},
+		{"constant", "2 * e - e", FloatValue(2.718281828459045)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Eval(%q) = %v (%v); expected %v (%v)",
+					tt.expr, result, result.Kind(), tt.expected, tt.expected.Kind())
+			}
+		})
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		err    error
+		column int
+	}{
+		{"division by zero", "1 + 4 / 0", ErrDivisionByZero, 7},
+		{"modulo by zero", "4 % (2 - 2)", ErrDivisionByZero, 3},
+		{"float division by zero", "1.5 / 0", ErrDivisionByZero, 5},
+		{"zero to a negative power", "0^-1", ErrDivisionByZero, 2},
+		{"float zero to a negative power", "1 + 0.0^-0.5", ErrDivisionByZero, 8},
+		{"undefined identifier", "2 * x", ErrUndefined, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Eval(tt.expr)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Eval(%q) error = %v; expected %v", tt.expr, err, tt.err)
+			}
+			var evalErr *EvalError
+			if !errors.As(err, &evalErr) || evalErr.Column != tt.column {
+				t.Errorf("Eval(%q) error = %v; expected column %d",
+					tt.expr, err, tt.column)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Vars(t *testing.T) {
+	e := NewEvaluator()
+	e.Vars["x"] = IntValue(3)
+	e.Vars["e"] = IntValue(10)
+
+	result, err := e.Eval("x^2 + e")
+	if err != nil {
+		t.Fatalf("Eval error: %v", err)
+	}
+	if result != IntValue(19) {
+		t.Errorf("Eval(%q) = %v; expected 19", "x^2 + e", result)
+	}
+
+	if result, err := e.Eval("y = x * 2"); err != nil || result != IntValue(6) {
+		t.Errorf("Eval(%q) = %v, %v; expected 6", "y = x * 2", result, err)
+	}
+	if e.Vars["y"] != IntValue(6) {
+		t.Errorf("Vars[y] = %v; expected 6", e.Vars["y"])
+	}
+}
+
+func TestEval_Overflow(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"addition", "9223372036854775807 + 1"},
+		{"subtraction", "-9223372036854775807 - 2"},
+		{"multiplication", "4611686018427387904 * 2"},
+		{"power", "2^63"},
+		{"negation", "-(-9223372036854775807 - 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Eval(tt.expr)
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("Eval(%q) error = %v; expected %v", tt.expr, err, ErrOverflow)
+			}
+		})
+	}
+
+	if result, err := Eval("2^62"); err != nil || result != IntValue(1<<62) {
+		t.Errorf("Eval(%q) = %v, %v; expected %d", "2^62", result, err, 1<<62)
+	}
+}
@@ -0,0 +1,247 @@
+package calculate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+var (
+	ErrArity          = errors.New("wrong number of arguments")
+	ErrRecursionDepth = errors.New("maximum recursion depth exceeded")
+)
+
+// DefaultMaxDepth bounds nested user function calls when
+// Evaluator.MaxDepth is zero.
+const DefaultMaxDepth = 256
+
+// builtin is a function implemented in Go. maxArgs < 0 means variadic.
+type builtin struct {
+	minArgs, maxArgs int
+	fn               func(e *Evaluator, args []Value) (Value, error)
+}
+
+// Func is a user-defined function.
+type Func struct {
+	Params []string
+	Body   Node
+}
+
+var builtins = map[string]builtin{
+	"sqrt":  math1(math.Sqrt, cmplx.Sqrt),
+	"sin":   math1(math.Sin, cmplx.Sin),
+	"cos":   math1(math.Cos, cmplx.Cos),
+	"tan":   math1(math.Tan, cmplx.Tan),
+	"exp":   math1(math.Exp, cmplx.Exp),
+	"floor": rounding(math.Floor),
+	"ceil":  rounding(math.Ceil),
+	"round": rounding(math.Round),
+	// log(x) is the natural logarithm; log(x, b) takes the base second.
+	"log": {1, 2, func(e *Evaluator, args []Value) (Value, error) {
+		log := math1(math.Log, cmplx.Log).fn
+		x, _ := log(e, args[:1])
+		if len(args) == 1 {
+			return x, nil
+		}
+		b, _ := log(e, args[1:])
+		if x.kind != KindComplex && b.kind != KindComplex {
+			return FloatValue(x.f / b.f), nil
+		}
+		return complexBinary(OpDiv, x.Complex128(), b.Complex128())
+	}},
+	"pow": {2, 2, func(e *Evaluator, args []Value) (Value, error) {
+		return e.binary(OpPow, args[0], args[1])
+	}},
+	"div": {2, 2, func(e *Evaluator, args []Value) (Value, error) {
+		q, _, err := divMod(args[0], args[1], e.DivMode)
+		return q, err
+	}},
+	"mod": {2, 2, func(e *Evaluator, args []Value) (Value, error) {
+		_, r, err := divMod(args[0], args[1], e.DivMode)
+		return r, err
+	}},
+	"abs": {1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		switch args[0].kind {
+		case KindFloat:
+			return FloatValue(math.Abs(args[0].f)), nil
+		case KindComplex:
+			return FloatValue(cmplx.Abs(args[0].c)), nil
+		}
+		if args[0].Float64() < 0 {
+			return negate(args[0])
+		}
+		return args[0], nil
+	}},
+	"arg": {1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		return FloatValue(cmplx.Phase(args[0].Complex128())), nil
+	}},
+	"re": {1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		if args[0].kind == KindComplex {
+			return FloatValue(real(args[0].c)), nil
+		}
+		return args[0], nil
+	}},
+	"im": {1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		if args[0].kind == KindComplex {
+			return FloatValue(imag(args[0].c)), nil
+		}
+		return IntValue(0), nil
+	}},
+	"conj": {1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		if args[0].kind == KindComplex {
+			return ComplexValue(cmplx.Conj(args[0].c)), nil
+		}
+		return args[0], nil
+	}},
+	"popcount": {1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		if args[0].kind != KindInt {
+			return Value{}, fmt.Errorf("%w: %v", ErrNotInteger, args[0])
+		}
+		return IntValue(PopCount(args[0].i)), nil
+	}},
+	"xor": {2, 2, func(e *Evaluator, args []Value) (Value, error) {
+		return bitwise(OpXor, args[0], args[1])
+	}},
+	"min": {1, -1, func(e *Evaluator, args []Value) (Value, error) {
+		return extreme(args, -1)
+	}},
+	"max": {1, -1, func(e *Evaluator, args []Value) (Value, error) {
+		return extreme(args, 1)
+	}},
+}
+
+// math1 wraps a real function f and its complex counterpart cf. Complex
+// arguments go to cf, as do real ones outside the domain of f, such as
+// the square root of a negative number.
+func math1(f func(float64) float64, cf func(complex128) complex128) builtin {
+	return builtin{1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		if args[0].kind == KindComplex {
+			return ComplexValue(cf(args[0].c)), nil
+		}
+		x := args[0].Float64()
+		if r := f(x); !math.IsNaN(r) || math.IsNaN(x) || math.IsInf(x, 0) {
+			return FloatValue(r), nil
+		}
+		return ComplexValue(cf(complex(x, 0))), nil
+	}}
+}
+
+// rounding wraps floor, ceil and round: integers pass through and results
+// that fit an int come back as one.
+func rounding(f func(float64) float64) builtin {
+	return builtin{1, 1, func(e *Evaluator, args []Value) (Value, error) {
+		if _, ok := args[0].Int(); ok {
+			return args[0], nil
+		}
+		if args[0].kind == KindComplex {
+			return Value{}, fmt.Errorf("%w: %v", ErrComplex, args[0])
+		}
+		r := f(args[0].Float64())
+		if r >= math.MinInt64 && r < math.MaxInt64 {
+			return IntValue(int(r)), nil
+		}
+		return FloatValue(r), nil
+	}}
+}
+
+// extreme returns the smallest (sign < 0) or largest argument, keeping its
+// kind. Complex numbers are not ordered.
+func extreme(args []Value, sign int) (Value, error) {
+	best := args[0]
+	for _, v := range args {
+		if v.kind == KindComplex {
+			return Value{}, fmt.Errorf("%w: %v", ErrComplex, v)
+		}
+		if c := compare(v, best); c < 0 && sign < 0 || c > 0 && sign > 0 {
+			best = v
+		}
+	}
+	return best, nil
+}
+
+// compare orders two real values. Ints and rationals compare exactly, so
+// ints above 2^53 are not rounded; anything involving a float compares as
+// floats, and NaN is neither smaller nor larger than anything.
+func compare(a, b Value) int {
+	switch {
+	case a.kind == KindInt && b.kind == KindInt:
+		switch {
+		case a.i < b.i:
+			return -1
+		case a.i > b.i:
+			return 1
+		}
+		return 0
+	case a.kind != KindFloat && b.kind != KindFloat:
+		return a.rational().Cmp(b.rational())
+	}
+	switch x, y := a.Float64(), b.Float64(); {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func checkArity(name string, got, min, max int) error {
+	if got < min || max >= 0 && got > max {
+		want := fmt.Sprint(min)
+		switch {
+		case max < 0:
+			want = fmt.Sprintf("at least %d", min)
+		case max != min:
+			want = fmt.Sprintf("%d to %d", min, max)
+		}
+		return fmt.Errorf("%w: %s takes %s, got %d", ErrArity, name, want, got)
+	}
+	return nil
+}
+
+// aborted reports whether err stops the whole evaluation rather than
+// failing one call.
+func aborted(err error) bool {
+	return errors.Is(err, ErrRecursionDepth) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+func (e *Evaluator) call(name string, args []Value) (Value, error) {
+	if f, ok := e.Funcs[name]; ok {
+		if err := checkArity(name, len(args), len(f.Params), len(f.Params)); err != nil {
+			return Value{}, err
+		}
+		maxDepth := e.MaxDepth
+		if maxDepth == 0 {
+			maxDepth = DefaultMaxDepth
+		}
+		if len(e.scopes) >= maxDepth {
+			return Value{}, ErrRecursionDepth
+		}
+		if err := e.done(); err != nil {
+			return Value{}, err
+		}
+
+		scope := make(map[string]Value, len(args))
+		for i, param := range f.Params {
+			scope[param] = args[i]
+		}
+		e.scopes = append(e.scopes, scope)
+		v, err := e.EvalNode(f.Body)
+		e.scopes = e.scopes[:len(e.scopes)-1]
+		if err != nil && !aborted(err) {
+			err = fmt.Errorf("in %s: %w", name, err)
+		}
+		return v, err
+	}
+
+	b, ok := builtins[name]
+	if !ok {
+		return Value{}, fmt.Errorf("%w: function %q", ErrUndefined, name)
+	}
+	if err := checkArity(name, len(args), b.minArgs, b.maxArgs); err != nil {
+		return Value{}, err
+	}
+	return b.fn(e, args)
+}
@@ -0,0 +1,177 @@
+package calculate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBuiltins_TableDriven(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected Value
+	}{
+		{"sqrt", "sqrt(16)", FloatValue(4)},
+		{"sin", "sin(0)", FloatValue(0)},
+		{"cos", "cos(pi)", FloatValue(-1)},
+		{"log", "log(e^2)", FloatValue(2)},
+		{"log base", "log(8, 2)", FloatValue(3)},
+		{"exp", "exp(0)", FloatValue(1)},
+		{"pow int", "pow(3, 4)", IntValue(81)},
+		{"pow float", "pow(2, 0.5)", FloatValue(math.Sqrt2)},
+		{"abs int", "abs(-7)", IntValue(7)},
+		{"abs float", "abs(-2.5)", FloatValue(2.5)},
+		{"floor", "floor(-2.5)", IntValue(-3)},
+		{"ceil", "ceil(2.1)", IntValue(3)},
+		{"round", "round(2.5)", IntValue(3)},
+		{"round int", "round(7)", IntValue(7)},
+		{"min", "min(3, -1.5, 2)", FloatValue(-1.5)},
+		{"max keeps kind", "max(1.5, 4, 2)", IntValue(4)},
+		{"nested", "max(abs(-3), sqrt(4)) * 2", IntValue(6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+			if result.Kind() != tt.expected.Kind() || math.Abs(result.Float64()-tt.expected.Float64()) > 1e-12 {
+				t.Errorf("Eval(%q) = %v (%v); expected %v (%v)",
+					tt.expr, result, result.Kind(), tt.expected, tt.expected.Kind())
+			}
+		})
+	}
+}
+
+func TestExtreme_Exact(t *testing.T) {
+	e := NewEvaluator()
+	e.Exact = true
+	tests := []struct {
+		expr     string
+		expected Value
+	}{
+		{"max(9007199254740993, 9007199254740992)", IntValue(9007199254740993)},
+		{"min(9007199254740993, 9007199254740992)", IntValue(9007199254740992)},
+		{"max(9007199254740992, 9007199254740993)", IntValue(9007199254740993)},
+		{"min(1/3, 1/4, 1)", RationalValue(Rational{num: 1, den: 4})},
+		{"max(2/3, 0.5, 1/2)", RationalValue(Rational{num: 2, den: 3})},
+	}
+
+	for _, tt := range tests {
+		result, err := e.Eval(tt.expr)
+		if err != nil {
+			t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+		}
+		if result != tt.expected {
+			t.Errorf("Eval(%q) = %v; expected %v", tt.expr, result, tt.expected)
+		}
+	}
+}
+
+func TestUserFunctions(t *testing.T) {
+	e := NewEvaluator()
+	for _, def := range []string{"f(x) = x^2 + 1", "hyp(a, b) = sqrt(a^2 + b^2)", "k = 10", "g(x) = f(x) * k"} {
+		if _, err := e.Eval(def); err != nil {
+			t.Fatalf("Eval(%q) error: %v", def, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected Value
+	}{
+		{"simple", "f(3)", IntValue(10)},
+		{"two params", "hyp(3, 4)", FloatValue(5)},
+		{"nested calls", "f(f(1))", IntValue(5)},
+		{"globals visible", "g(2)", IntValue(50)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := e.Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Eval(%q) = %v; expected %v", tt.expr, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFunctions_Errors(t *testing.T) {
+	e := NewEvaluator()
+	e.MaxDepth = 50
+	for _, def := range []string{"f(x) = x + 1", "loop(n) = loop(n + 1)", "leak(a) = a + b"} {
+		if _, err := e.Eval(def); err != nil {
+			t.Fatalf("Eval(%q) error: %v", def, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		err  error
+	}{
+		{"user arity", "f(1, 2)", ErrArity},
+		{"builtin arity", "sqrt()", ErrArity},
+		{"variadic arity", "min()", ErrArity},
+		{"unknown function", "nope(1)", ErrUndefined},
+		{"recursion depth", "loop(0)", ErrRecursionDepth},
+		{"params do not leak", "f(1) + a", ErrUndefined},
+		{"no dynamic scope", "leak(1)", ErrUndefined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.Eval(tt.expr)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Eval(%q) error = %v; expected %v", tt.expr, err, tt.err)
+			}
+		})
+	}
+
+	if _, err := e.Eval("nope(1)"); err == nil || err.Error() != `column 1: undefined identifier: function "nope"` {
+		t.Errorf("unknown function error = %v; expected it to name the function", err)
+	}
+	if _, err := e.Eval("sin(x) = x"); err == nil {
+		t.Errorf("redefining sin succeeded; expected an error")
+	}
+}
+
+func TestEvalContext_Timeout(t *testing.T) {
+	e := NewEvaluator()
+	// f40 makes 2^40 calls without nesting deeper than 40.
+	e.Eval("f0(x) = x")
+	for i := 1; i <= 40; i++ {
+		e.Eval(fmt.Sprintf("f%d(x) = f%d(x) + f%d(x)", i, i-1, i-1))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := e.EvalContext(ctx, "1 + f40(1)")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("EvalContext error = %v; expected %v", err, context.DeadlineExceeded)
+	}
+	var evalErr *EvalError
+	if !errors.As(err, &evalErr) || evalErr.Column != 5 {
+		t.Errorf("error %v should be reported at the outermost call, column 5", err)
+	}
+
+	if v, err := e.Eval("2 + 2"); err != nil || v != IntValue(4) {
+		t.Errorf("Eval after timeout = %v, %v; expected 4", v, err)
+	}
+
+	// Powers check the context too, not only function calls.
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := e.EvalContext(done, "1 + 2^3"); !errors.Is(err, context.Canceled) {
+		t.Errorf("EvalContext of a power error = %v; expected %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,374 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidInterval = errors.New("invalid interval")
+
+// Interval is the closed range [Lo, Hi] of values a quantity may take.
+// Arithmetic rounds bounds outward whenever a float64 result is inexact,
+// so the true result always lies inside the computed interval.
+type Interval struct {
+	Lo, Hi float64
+}
+
+// NewInterval returns [lo, hi]; lo must not exceed hi.
+func NewInterval(lo, hi float64) (Interval, error) {
+	if !(lo <= hi) {
+		return Interval{}, fmt.Errorf("%w: [%v, %v]", ErrInvalidInterval, lo, hi)
+	}
+	return Interval{lo, hi}, nil
+}
+
+// Point returns the degenerate interval [x, x].
+func Point(x float64) Interval {
+	return Interval{x, x}
+}
+
+// Uncertain returns x ± delta, as for a measurement with known tolerance.
+func Uncertain(x, delta float64) Interval {
+	delta = math.Abs(delta)
+	lo, _ := addRounded(x, -delta)
+	_, hi := addRounded(x, delta)
+	return Interval{lo, hi}
+}
+
+func (x Interval) Width() float64 { return x.Hi - x.Lo }
+func (x Interval) Mid() float64   { return x.Lo + (x.Hi-x.Lo)/2 }
+
+func (x Interval) Contains(v float64) bool {
+	return x.Lo <= v && v <= x.Hi
+}
+
+func (x Interval) Neg() Interval {
+	return Interval{-x.Hi, -x.Lo}
+}
+
+func (x Interval) Add(y Interval) Interval {
+	lo, _ := addRounded(x.Lo, y.Lo)
+	_, hi := addRounded(x.Hi, y.Hi)
+	return Interval{lo, hi}
+}
+
+func (x Interval) Sub(y Interval) Interval {
+	return x.Add(y.Neg())
+}
+
+// Mul takes the extremes of the four endpoint products, which covers
+// every combination of signs.
+func (x Interval) Mul(y Interval) Interval {
+	r := Interval{math.Inf(1), math.Inf(-1)}
+	for _, a := range [2]float64{x.Lo, x.Hi} {
+		for _, b := range [2]float64{y.Lo, y.Hi} {
+			lo, hi := mulRounded(a, b)
+			r.Lo, r.Hi = math.Min(r.Lo, lo), math.Max(r.Hi, hi)
+		}
+	}
+	return r
+}
+
+// Div divides by y. When y contains zero the quotient is unbounded: a
+// half-line if zero is an endpoint of y and x does not straddle zero,
+// otherwise the whole real line. Dividing by [0, 0] is an error.
+func (x Interval) Div(y Interval) (Interval, error) {
+	inf := math.Inf(1)
+	switch {
+	case y.Lo == 0 && y.Hi == 0:
+		return Interval{}, ErrDivisionByZero
+	case !y.Contains(0):
+		return x.Mul(y.reciprocal()), nil
+	case x.Lo == 0 && x.Hi == 0:
+		return x, nil
+	case y.Lo == 0 && x.Lo >= 0, y.Hi == 0 && x.Hi <= 0:
+		lo, _ := divRounded(x.Lo, y.Hi)
+		if y.Hi == 0 {
+			lo, _ = divRounded(x.Hi, y.Lo)
+		}
+		return Interval{lo, inf}, nil
+	case y.Lo == 0 && x.Hi <= 0, y.Hi == 0 && x.Lo >= 0:
+		_, hi := divRounded(x.Hi, y.Hi)
+		if y.Hi == 0 {
+			_, hi = divRounded(x.Lo, y.Lo)
+		}
+		return Interval{-inf, hi}, nil
+	}
+	return Interval{-inf, inf}, nil
+}
+
+// reciprocal returns 1/y for y not containing zero.
+func (y Interval) reciprocal() Interval {
+	lo, _ := divRounded(1, y.Hi)
+	_, hi := divRounded(1, y.Lo)
+	return Interval{lo, hi}
+}
+
+// Pow raises x to an integer power. Even powers of an interval around zero
+// start at zero; negative powers divide and fail like Div.
+func (x Interval) Pow(n int) (Interval, error) {
+	if n < 0 {
+		return Point(1).Div(x.pow(absInt(n)))
+	}
+	return x.pow(uint64(n)), nil
+}
+
+func (x Interval) pow(n uint64) Interval {
+	abs := x.Abs()
+	lo, _ := powRounded(abs.Lo, n)
+	_, hi := powRounded(abs.Hi, n)
+	switch {
+	case n%2 == 1 && x.Hi <= 0:
+		return Interval{-hi, -lo}
+	case n%2 == 1 && x.Lo < 0:
+		_, neg := powRounded(-x.Lo, n)
+		_, pos := powRounded(x.Hi, n)
+		return Interval{-neg, pos}
+	}
+	return Interval{lo, hi}
+}
+
+func (x Interval) Abs() Interval {
+	switch {
+	case x.Lo >= 0:
+		return x
+	case x.Hi <= 0:
+		return x.Neg()
+	}
+	return Interval{0, math.Max(-x.Lo, x.Hi)}
+}
+
+func (x Interval) Sqrt() (Interval, error) {
+	if x.Hi < 0 {
+		return Interval{}, fmt.Errorf("%w: sqrt of %v", ErrInvalidInterval, x)
+	}
+	return monotone(math.Sqrt, Interval{math.Max(x.Lo, 0), x.Hi}), nil
+}
+
+// Hull returns the smallest interval containing both x and y.
+func (x Interval) Hull(y Interval) Interval {
+	return Interval{math.Min(x.Lo, y.Lo), math.Max(x.Hi, y.Hi)}
+}
+
+// Intersect returns the common part of x and y and whether there is one.
+func (x Interval) Intersect(y Interval) (Interval, bool) {
+	r := Interval{math.Max(x.Lo, y.Lo), math.Min(x.Hi, y.Hi)}
+	return r, r.Lo <= r.Hi
+}
+
+func (x Interval) String() string {
+	return "[" + strconv.FormatFloat(x.Lo, 'g', -1, 64) + ", " + strconv.FormatFloat(x.Hi, 'g', -1, 64) + "]"
+}
+
+// monotone applies an increasing library function to both bounds and
+// widens the result by one ulp, the accuracy math promises.
+func monotone(f func(float64) float64, x Interval) Interval {
+	return Interval{math.Nextafter(f(x.Lo), math.Inf(-1)), math.Nextafter(f(x.Hi), math.Inf(1))}
+}
+
+// addRounded returns a+b rounded down and up. The error of the rounded sum
+// is computed exactly (Knuth's TwoSum), so exact sums are not widened.
+func addRounded(a, b float64) (lo, hi float64) {
+	s := a + b
+	if math.IsInf(s, 0) && !math.IsInf(a, 0) && !math.IsInf(b, 0) {
+		return overflowed(s)
+	}
+	if math.IsInf(s, 0) || math.IsNaN(s) {
+		return s, s
+	}
+	bb := s - a
+	return widen(s, (a-(s-bb))+(b-bb))
+}
+
+// mulRounded is addRounded for a*b, taking the error from a fused
+// multiply-add. Zero times infinity counts as zero.
+func mulRounded(a, b float64) (lo, hi float64) {
+	if a == 0 || b == 0 {
+		return 0, 0
+	}
+	p := a * b
+	if math.IsInf(p, 0) && !math.IsInf(a, 0) && !math.IsInf(b, 0) {
+		return overflowed(p)
+	}
+	if math.IsInf(p, 0) {
+		return p, p
+	}
+	return widen(p, math.FMA(a, b, -p))
+}
+
+func divRounded(a, b float64) (lo, hi float64) {
+	q := a / b
+	if math.IsInf(q, 0) && !math.IsInf(a, 0) && b != 0 {
+		return overflowed(q)
+	}
+	if math.IsInf(q, 0) || math.IsNaN(q) || math.IsInf(b, 0) {
+		return q, q
+	}
+	// a - q*b is exact; the true quotient exceeds q when it has b's sign.
+	r := math.FMA(-q, b, a)
+	if b < 0 {
+		r = -r
+	}
+	return widen(q, r)
+}
+
+// overflowed bounds a result of finite operands that rounded to ±Inf: the
+// true value is finite, so the inner bound is the largest float.
+func overflowed(r float64) (lo, hi float64) {
+	if r > 0 {
+		return math.MaxFloat64, r
+	}
+	return r, -math.MaxFloat64
+}
+
+// powRounded bounds a^n for a >= 0 by repeated squaring, rounding the
+// lower bound down and the upper bound up at every step.
+func powRounded(a float64, n uint64) (lo, hi float64) {
+	lo, hi = 1, 1
+	for loBase, hiBase := a, a; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			lo, _ = mulRounded(lo, loBase)
+			_, hi = mulRounded(hi, hiBase)
+		}
+		if n > 1 {
+			loBase, _ = mulRounded(loBase, loBase)
+			_, hiBase = mulRounded(hiBase, hiBase)
+		}
+	}
+	return lo, hi
+}
+
+// widen returns the bounds of v + err where v is the rounded result.
+func widen(v, err float64) (lo, hi float64) {
+	switch {
+	case err > 0:
+		return v, math.Nextafter(v, math.Inf(1))
+	case err < 0:
+		return math.Nextafter(v, math.Inf(-1)), v
+	}
+	return v, v
+}
+
+var intervalFuncs = map[string]func(Interval) (Interval, error){
+	"sqrt": Interval.Sqrt,
+	"abs":  func(x Interval) (Interval, error) { return x.Abs(), nil },
+	"exp":  func(x Interval) (Interval, error) { return monotone(math.Exp, x), nil },
+	"log": func(x Interval) (Interval, error) {
+		if x.Hi <= 0 {
+			return Interval{}, fmt.Errorf("%w: log of %v", ErrInvalidInterval, x)
+		}
+		return monotone(math.Log, Interval{math.Max(x.Lo, 0), x.Hi}), nil
+	},
+}
+
+// EvalInterval evaluates expr with every variable standing for an
+// interval, giving bounds on the result. It supports + - * /, integer
+// powers, pi and e, and the functions sqrt, abs, exp and log.
+func EvalInterval(expr string, vars map[string]Interval) (Interval, error) {
+	n, err := Parse(expr)
+	if err != nil {
+		return Interval{}, err
+	}
+	return evalInterval(n, vars)
+}
+
+// literalInterval bounds the number n.Text stands for. A literal such as
+// 0.1 that float64 cannot hold is widened by an ulp, like pi and e.
+func literalInterval(n *NumberLit) Interval {
+	f := n.Value.Float64()
+	switch n.Value.kind {
+	case KindInt:
+		if f >= math.MinInt64 && f < math.MaxInt64 && int(f) == n.Value.i {
+			return Point(f)
+		}
+	case KindFloat:
+		exact, ok := new(big.Rat).SetString(strings.ReplaceAll(n.Text, "_", ""))
+		if ok && !math.IsInf(f, 0) && exact.Cmp(new(big.Rat).SetFloat64(f)) == 0 {
+			return Point(f)
+		}
+	}
+	return monotone(func(f float64) float64 { return f }, Point(f))
+}
+
+func evalInterval(n Node, vars map[string]Interval) (Interval, error) {
+	switch n := n.(type) {
+	case *NumberLit:
+		return literalInterval(n), nil
+	case *Ident:
+		if x, ok := vars[n.Name]; ok {
+			return x, nil
+		}
+		if c, ok := constants[n.Name]; ok && c.kind != KindComplex {
+			// The float64 constant is rounded; the true value is within an ulp.
+			return monotone(func(f float64) float64 { return f }, Point(c.f)), nil
+		}
+		return Interval{}, &EvalError{Column: n.Column, Err: fmt.Errorf("%w %q", ErrUndefined, n.Name)}
+	case *UnaryExpr:
+		x, err := evalInterval(n.X, vars)
+		if err != nil {
+			return Interval{}, err
+		}
+		switch n.Op {
+		case OpAdd:
+			return x, nil
+		case OpSub:
+			return x.Neg(), nil
+		}
+	case *BinaryExpr:
+		x, err := evalInterval(n.X, vars)
+		if err != nil {
+			return Interval{}, err
+		}
+		y, err := evalInterval(n.Y, vars)
+		if err != nil {
+			return Interval{}, err
+		}
+		var r Interval
+		switch n.Op {
+		case OpAdd:
+			return x.Add(y), nil
+		case OpSub:
+			return x.Sub(y), nil
+		case OpMul:
+			return x.Mul(y), nil
+		case OpDiv:
+			r, err = x.Div(y)
+		case OpPow:
+			if y.Lo != y.Hi || y.Lo != math.Trunc(y.Lo) {
+				err = fmt.Errorf("%w: exponent %v is not an integer", ErrInvalidInterval, y)
+				break
+			}
+			if math.Abs(y.Lo) > 1<<53 {
+				err = fmt.Errorf("%w: exponent %v is too large", ErrInvalidInterval, y.Lo)
+				break
+			}
+			r, err = x.Pow(int(y.Lo))
+		default:
+			err = fmt.Errorf("%w: unsupported operator %v", ErrInvalidInterval, n.Op)
+		}
+		if err != nil {
+			return Interval{}, &EvalError{Column: n.Column, Err: err}
+		}
+		return r, nil
+	case *CallExpr:
+		f, ok := intervalFuncs[n.Name]
+		if !ok || len(n.Args) != 1 {
+			return Interval{}, &EvalError{Column: n.Column,
+				Err: fmt.Errorf("%w: unsupported function %v", ErrInvalidInterval, n)}
+		}
+		x, err := evalInterval(n.Args[0], vars)
+		if err != nil {
+			return Interval{}, err
+		}
+		r, err := f(x)
+		if err != nil {
+			return Interval{}, &EvalError{Column: n.Column, Err: err}
+		}
+		return r, nil
+	}
+	return Interval{}, &EvalError{Column: n.Pos(), Err: fmt.Errorf("%w: unsupported %v", ErrInvalidInterval, n)}
+}
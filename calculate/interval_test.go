@@ -0,0 +1,146 @@
+package calculate
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func iv(lo, hi float64) Interval {
+	return Interval{lo, hi}
+}
+
+func TestInterval_Arithmetic(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name     string
+		result   func() (Interval, error)
+		expected Interval
+	}{
+		{"add", func() (Interval, error) { return iv(1, 2).Add(iv(10, 20)), nil }, iv(11, 22)},
+		{"sub", func() (Interval, error) { return iv(1, 2).Sub(iv(10, 20)), nil }, iv(-19, -8)},
+		{"mul positive", func() (Interval, error) { return iv(1, 2).Mul(iv(3, 4)), nil }, iv(3, 8)},
+		{"mul negative endpoints", func() (Interval, error) { return iv(-2, 3).Mul(iv(-5, 4)), nil }, iv(-15, 12)},
+		{"mul both negative", func() (Interval, error) { return iv(-3, -2).Mul(iv(-5, -4)), nil }, iv(8, 15)},
+		{"mul zero and infinity", func() (Interval, error) { return iv(0, 1).Mul(iv(1, inf)), nil }, iv(0, inf)},
+		{"div", func() (Interval, error) { return iv(1, 2).Div(iv(4, 8)) }, iv(0.125, 0.5)},
+		{"div negative", func() (Interval, error) { return iv(2, 4).Div(iv(-2, -1)) }, iv(-4, -1)},
+		{"div by [0, b]", func() (Interval, error) { return iv(1, 2).Div(iv(0, 4)) }, iv(0.25, inf)},
+		{"div negative by [0, b]", func() (Interval, error) { return iv(-2, -1).Div(iv(0, 4)) }, iv(-inf, -0.25)},
+		{"div by [a, 0]", func() (Interval, error) { return iv(1, 2).Div(iv(-4, 0)) }, iv(-inf, -0.25)},
+		{"div negative by [a, 0]", func() (Interval, error) { return iv(-2, -1).Div(iv(-4, 0)) }, iv(0.25, inf)},
+		{"div by interval around zero", func() (Interval, error) { return iv(1, 2).Div(iv(-1, 1)) }, iv(-inf, inf)},
+		{"div straddling by [0, b]", func() (Interval, error) { return iv(-1, 2).Div(iv(0, 1)) }, iv(-inf, inf)},
+		{"zero div", func() (Interval, error) { return iv(0, 0).Div(iv(-1, 1)) }, iv(0, 0)},
+		{"even power around zero", func() (Interval, error) { return iv(-3, 2).Pow(2) }, iv(0, 9)},
+		{"even power negative", func() (Interval, error) { return iv(-3, -2).Pow(2) }, iv(4, 9)},
+		{"odd power", func() (Interval, error) { return iv(-3, 2).Pow(3) }, iv(-27, 8)},
+		{"odd power negative", func() (Interval, error) { return iv(-3, -2).Pow(3) }, iv(-27, -8)},
+		{"negative power", func() (Interval, error) { return iv(2, 4).Pow(-1) }, iv(0.25, 0.5)},
+		{"zeroth power", func() (Interval, error) { return iv(-1, 1).Pow(0) }, iv(1, 1)},
+		{"huge power", func() (Interval, error) { return iv(0.5, 1).Pow(math.MaxInt) }, iv(0, 1)},
+		{"huge negative power", func() (Interval, error) { return iv(1, 2).Pow(math.MinInt) }, iv(0, 1)},
+		{"abs", func() (Interval, error) { return iv(-3, 2).Abs(), nil }, iv(0, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.result()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("got %v; expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInterval_OutwardRounding(t *testing.T) {
+	// 0.1 + 0.2 is not exactly representable; the bounds must straddle the
+	// rounded float result.
+	sum := Point(0.1).Add(Point(0.2))
+	if sum.Lo == sum.Hi || !sum.Contains(0.1+0.2) {
+		t.Errorf("0.1 + 0.2 = %v; expected a one-ulp interval around %v", sum, 0.1+0.2)
+	}
+	third, _ := Point(1).Div(Point(3))
+	if third.Lo >= third.Hi || third.Hi-third.Lo > 1e-16 {
+		t.Errorf("1/3 = %v; expected a one-ulp interval", third)
+	}
+	if r := Point(3).Mul(Point(0.5)); r != Point(1.5) {
+		t.Errorf("3 * 0.5 = %v; exact products should not widen", r)
+	}
+	// An overflowing result is finite, so only the outer bound is infinite.
+	if r := Point(math.MaxFloat64).Mul(Point(-2)); r.Lo != math.Inf(-1) || r.Hi != -math.MaxFloat64 {
+		t.Errorf("MaxFloat64 * -2 = %v; expected [-Inf, -MaxFloat64]", r)
+	}
+	if r := Point(math.MaxFloat64).Add(Point(math.MaxFloat64)); r.Lo != math.MaxFloat64 || r.Hi != math.Inf(1) {
+		t.Errorf("MaxFloat64 + MaxFloat64 = %v; expected [MaxFloat64, +Inf]", r)
+	}
+	if r, _ := Point(math.MaxFloat64).Div(Point(0.5)); r.Lo != math.MaxFloat64 || r.Hi != math.Inf(1) {
+		t.Errorf("MaxFloat64 / 0.5 = %v; expected [MaxFloat64, +Inf]", r)
+	}
+}
+
+func TestInterval_Errors(t *testing.T) {
+	if _, err := iv(1, 2).Div(iv(0, 0)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Div by [0, 0] error = %v; expected %v", err, ErrDivisionByZero)
+	}
+	if _, err := NewInterval(2, 1); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("NewInterval(2, 1) error = %v; expected %v", err, ErrInvalidInterval)
+	}
+	if _, err := iv(-2, -1).Sqrt(); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Sqrt of negative interval error = %v; expected %v", err, ErrInvalidInterval)
+	}
+	if r, ok := iv(0, 1).Intersect(iv(2, 3)); ok {
+		t.Errorf("Intersect of disjoint intervals = %v; expected none", r)
+	}
+}
+
+func TestEvalInterval(t *testing.T) {
+	// BMI with a mass of 70 ± 0.5 kg and a height of 1.75 ± 0.01 m.
+	vars := map[string]Interval{"m": Uncertain(70, 0.5), "h": Uncertain(1.75, 0.01)}
+	bmi, err := EvalInterval("m / h^2", vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lo, hi := 69.5/(1.76*1.76), 70.5/(1.74*1.74)
+	if math.Abs(bmi.Lo-lo) > 1e-12 || math.Abs(bmi.Hi-hi) > 1e-12 || !bmi.Contains(70/(1.75*1.75)) {
+		t.Errorf("BMI = %v; expected about [%v, %v]", bmi, lo, hi)
+	}
+
+	tenth, err := EvalInterval("0.1", nil)
+	if err != nil || tenth.Lo >= 0.1 || tenth.Hi <= 0.1 {
+		t.Errorf("0.1 = %v, %v; expected an interval around the float 0.1", tenth, err)
+	}
+	for _, expr := range []string{"0.5", "1e3", "42", "0x1F"} {
+		if x, err := EvalInterval(expr, nil); err != nil || x.Lo != x.Hi {
+			t.Errorf("EvalInterval(%q) = %v, %v; exact literals should not widen", expr, x, err)
+		}
+	}
+	if x, err := EvalInterval("9007199254740993", nil); err != nil || !x.Contains(9007199254740992) || x.Lo == x.Hi {
+		t.Errorf("2^53 + 1 = %v, %v; expected a widened interval", x, err)
+	}
+
+	area, err := EvalInterval("pi * r^2", map[string]Interval{"r": iv(1, 2)})
+	if err != nil || !area.Contains(math.Pi) || !area.Contains(4*math.Pi) || area.Width() > 3*math.Pi+1e-12 {
+		t.Errorf("pi * r^2 = %v, %v; expected about [pi, 4pi]", area, err)
+	}
+
+	errs := []struct {
+		expr string
+		err  error
+	}{
+		{"x ^ 0.5", ErrInvalidInterval},
+		{"1 / (x - x)", ErrDivisionByZero},
+		{"y + 1", ErrUndefined},
+		{"sin(x)", ErrInvalidInterval},
+		{"x % 2", ErrInvalidInterval},
+		{"x ^ 1e300", ErrInvalidInterval},
+	}
+	for _, tt := range errs {
+		if _, err := EvalInterval(tt.expr, map[string]Interval{"x": iv(2, 2)}); !errors.Is(err, tt.err) {
+			t.Errorf("EvalInterval(%q) error = %v; expected %v", tt.expr, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package calculate
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokPlus
+	tokMinus
+	tokStar
+	tokSlash
+	tokPercent
+	tokCaret
+	tokStarStar
+	tokAmp
+	tokPipe
+	tokTilde
+	tokShl
+	tokShr
+	tokLParen
+	tokRParen
+	tokAssign
+	tokComma
+)
+
+var tokenNames = map[tokenKind]string{
+	tokEOF:      "end of input",
+	tokNumber:   "number",
+	tokIdent:    "identifier",
+	tokPlus:     "+",
+	tokMinus:    "-",
+	tokStar:     "*",
+	tokSlash:    "/",
+	tokPercent:  "%",
+	tokCaret:    "^",
+	tokStarStar: "**",
+	tokAmp:      "&",
+	tokPipe:     "|",
+	tokTilde:    "~",
+	tokShl:      "<<",
+	tokShr:      ">>",
+	tokLParen:   "(",
+	tokRParen:   ")",
+	tokAssign:   "=",
+	tokComma:    ",",
+}
+
+// doubled maps punctuation that forms a different token when repeated.
+var doubled = map[rune]tokenKind{
+	'*': tokStarStar,
+	'<': tokShl,
+	'>': tokShr,
+}
+
+var punctuation = map[rune]tokenKind{
+	'+': tokPlus,
+	'-': tokMinus,
+	'*': tokStar,
+	'/': tokSlash,
+	'%': tokPercent,
+	'^': tokCaret,
+	'&': tokAmp,
+	'|': tokPipe,
+	'~': tokTilde,
+	'(': tokLParen,
+	')': tokRParen,
+	'=': tokAssign,
+	',': tokComma,
+}
+
+func (k tokenKind) String() string {
+	return tokenNames[k]
+}
+
+// token is a single lexeme; column is its 1-based rune offset in the input.
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+type lexer struct {
+	src    string
+	pos    int
+	column int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, column: 1}
+}
+
+func (l *lexer) peekRune() rune {
+	if l.pos >= len(l.src) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return r
+}
+
+func (l *lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	l.pos += size
+	l.column++
+	return r
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(l.peekRune()) {
+		l.advance()
+	}
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, column: l.column}, nil
+	}
+
+	start, column := l.pos, l.column
+	r := l.peekRune()
+	switch {
+	case isDigit(r) || r == '.':
+		return l.number()
+	case unicode.IsLetter(r) || r == '_':
+		for l.pos < len(l.src) {
+			r := l.peekRune()
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+				break
+			}
+			l.advance()
+		}
+		return token{kind: tokIdent, text: l.src[start:l.pos], column: column}, nil
+	}
+
+	l.advance()
+	kind, ok := punctuation[r]
+	if k, isDoubled := doubled[r]; isDoubled && l.peekRune() == r {
+		l.advance()
+		kind, ok = k, true
+	}
+	if !ok {
+		return token{}, &SyntaxError{Column: column, Msg: "unexpected character " + quoteRune(r)}
+	}
+	return token{kind: kind, text: l.src[start:l.pos], column: column}, nil
+}
+
+func (l *lexer) number() (token, error) {
+	start, column := l.pos, l.column
+	if basePrefix(l.src[l.pos:]) != 0 && len(l.src) > l.pos+2 && isAlnum(rune(l.src[l.pos+2])) {
+		// A 0b, 0o or 0x literal; the digits are checked by ParseInt.
+		l.advance()
+		l.advance()
+		for isAlnum(l.peekRune()) {
+			l.advance()
+		}
+		return token{kind: tokNumber, text: l.src[start:l.pos], column: column}, nil
+	}
+	digits := 0
+	for isDigit(l.peekRune()) {
+		l.advance()
+		digits++
+	}
+	if l.peekRune() == '.' {
+		l.advance()
+		for isDigit(l.peekRune()) {
+			l.advance()
+			digits++
+		}
+	}
+	if digits == 0 {
+		return token{}, &SyntaxError{Column: column, Msg: "malformed number"}
+	}
+	if r := l.peekRune(); r == 'e' || r == 'E' {
+		save, saveColumn := l.pos, l.column
+		l.advance()
+		if r := l.peekRune(); r == '+' || r == '-' {
+			l.advance()
+		}
+		if !isDigit(l.peekRune()) {
+			// Not an exponent after all, e.g. "2e" where e is the constant.
+			l.pos, l.column = save, saveColumn
+		}
+		for isDigit(l.peekRune()) {
+			l.advance()
+		}
+	}
+	return token{kind: tokNumber, text: l.src[start:l.pos], column: column}, nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isAlnum(r rune) bool {
+	return isDigit(r) || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_'
+}
+
+func quoteRune(r rune) string {
+	if r == utf8.RuneError {
+		return "end of input"
+	}
+	return "'" + string(r) + "'"
+}
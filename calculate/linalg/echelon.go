@@ -0,0 +1,61 @@
+package linalg
+
+import "math"
+
+// RREF returns the reduced row echelon form of m together with the columns
+// that hold its pivots. Entries that are tiny relative to the largest
+// entry of their column in m count as zero, so a column of small
+// coefficients is not swamped by large values elsewhere, such as the
+// constants of an augmented matrix.
+func (m Matrix[T]) RREF() (Matrix[float64], []int) {
+	a := m.Float64()
+	tol := make([]float64, a.cols)
+	for i, x := range a.data {
+		tol[i%a.cols] = math.Max(tol[i%a.cols], math.Abs(x))
+	}
+	for j := range tol {
+		tol[j] *= float64(max(a.rows, a.cols)) * 1e-14
+	}
+
+	var pivots []int
+	row := 0
+	for col := 0; col < a.cols && row < a.rows; col++ {
+		p := row
+		for i := row + 1; i < a.rows; i++ {
+			if math.Abs(a.At(i, col)) > math.Abs(a.At(p, col)) {
+				p = i
+			}
+		}
+		if math.Abs(a.At(p, col)) <= tol[col] {
+			for i := row; i < a.rows; i++ {
+				a.Set(i, col, 0)
+			}
+			continue
+		}
+		for j := 0; j < a.cols; j++ {
+			a.data[row*a.cols+j], a.data[p*a.cols+j] = a.data[p*a.cols+j], a.data[row*a.cols+j]
+		}
+		f := a.At(row, col)
+		for j := col; j < a.cols; j++ {
+			a.data[row*a.cols+j] /= f
+		}
+		for i := 0; i < a.rows; i++ {
+			if i == row || a.At(i, col) == 0 {
+				continue
+			}
+			f := a.At(i, col)
+			for j := col; j < a.cols; j++ {
+				a.data[i*a.cols+j] -= f * a.At(row, j)
+			}
+			a.Set(i, col, 0)
+		}
+		pivots = append(pivots, col)
+		row++
+	}
+	return a, pivots
+}
+
+func (m Matrix[T]) Rank() int {
+	_, pivots := m.RREF()
+	return len(pivots)
+}
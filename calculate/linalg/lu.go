@@ -0,0 +1,113 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+
+	"golang-lessons/calculate"
+)
+
+// LU is the factorization P·A = L·U with partial pivoting. L (unit lower
+// triangular) and U share one matrix.
+type LU struct {
+	lu   Matrix[float64]
+	perm []int
+	sign float64
+}
+
+// Decompose factors a square matrix. It returns ErrSingular when a pivot
+// vanishes relative to the largest entry of its own row, so a matrix with
+// small but well-conditioned rows such as diag(1e-20, 1) is not singular.
+func Decompose[T calculate.Real](m Matrix[T]) (*LU, error) {
+	if m.rows != m.cols {
+		return nil, fmt.Errorf("%w: %dx%d", ErrNotSquare, m.rows, m.cols)
+	}
+	n := m.rows
+	a := m.Float64()
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+
+	// rowScale follows the rows through the pivoting swaps.
+	rowScale := make([]float64, n)
+	for i := range rowScale {
+		for j := 0; j < n; j++ {
+			rowScale[i] = math.Max(rowScale[i], math.Abs(a.At(i, j)))
+		}
+	}
+
+	sign := 1.0
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a.At(i, k)) > math.Abs(a.At(p, k)) {
+				p = i
+			}
+		}
+		if math.Abs(a.At(p, k)) <= rowScale[p]*float64(n)*1e-14 {
+			return nil, ErrSingular
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a.data[k*n+j], a.data[p*n+j] = a.data[p*n+j], a.data[k*n+j]
+			}
+			perm[k], perm[p] = perm[p], perm[k]
+			rowScale[k], rowScale[p] = rowScale[p], rowScale[k]
+			sign = -sign
+		}
+		for i := k + 1; i < n; i++ {
+			f := a.At(i, k) / a.At(k, k)
+			a.Set(i, k, f)
+			for j := k + 1; j < n; j++ {
+				a.data[i*n+j] -= f * a.At(k, j)
+			}
+		}
+	}
+	return &LU{lu: a, perm: perm, sign: sign}, nil
+}
+
+func (d *LU) Det() float64 {
+	det := d.sign
+	for i := 0; i < d.lu.rows; i++ {
+		det *= d.lu.At(i, i)
+	}
+	return det
+}
+
+// Solve returns x with A·x = b by forward and back substitution.
+func (d *LU) Solve(b Vector[float64]) (Vector[float64], error) {
+	n := d.lu.rows
+	if len(b) != n {
+		return nil, fmt.Errorf("%w: %dx%d system with %d values", ErrDimensionMismatch, n, n, len(b))
+	}
+	x := make(Vector[float64], n)
+	for i := 0; i < n; i++ {
+		x[i] = b[d.perm[i]]
+		for j := 0; j < i; j++ {
+			x[i] -= d.lu.At(i, j) * x[j]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= d.lu.At(i, j) * x[j]
+		}
+		x[i] /= d.lu.At(i, i)
+	}
+	return x, nil
+}
+
+func (d *LU) Inverse() Matrix[float64] {
+	n := d.lu.rows
+	inv := NewMatrix[float64](n, n)
+	e := make(Vector[float64], n)
+	for j := 0; j < n; j++ {
+		clear(e)
+		e[j] = 1
+		col, _ := d.Solve(e)
+		for i, x := range col {
+			inv.Set(i, j, x)
+		}
+	}
+	return inv
+}
@@ -0,0 +1,220 @@
+// Package linalg provides small dense vectors and matrices over the real
+// number types of package calculate. Decompositions and solving work in
+// float64 whatever the element type.
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang-lessons/calculate"
+)
+
+var (
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+	ErrNotSquare         = errors.New("matrix is not square")
+	ErrSingular          = errors.New("matrix is singular")
+)
+
+// Matrix is a dense row-major matrix. The zero value is a 0x0 matrix.
+type Matrix[T calculate.Real] struct {
+	rows, cols int
+	data       []T
+}
+
+func NewMatrix[T calculate.Real](rows, cols int) Matrix[T] {
+	return Matrix[T]{rows: rows, cols: cols, data: make([]T, rows*cols)}
+}
+
+// FromRows builds a matrix from equally long rows.
+func FromRows[T calculate.Real](rows [][]T) (Matrix[T], error) {
+	if len(rows) == 0 {
+		return Matrix[T]{}, nil
+	}
+	m := NewMatrix[T](len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix[T]{}, fmt.Errorf("%w: row %d has %d columns, expected %d",
+				ErrDimensionMismatch, i, len(row), m.cols)
+		}
+		copy(m.data[i*m.cols:], row)
+	}
+	return m, nil
+}
+
+func Identity[T calculate.Real](n int) Matrix[T] {
+	m := NewMatrix[T](n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+func (m Matrix[T]) Rows() int { return m.rows }
+func (m Matrix[T]) Cols() int { return m.cols }
+
+func (m Matrix[T]) At(i, j int) T {
+	return m.data[i*m.cols+j]
+}
+
+func (m Matrix[T]) Set(i, j int, v T) {
+	m.data[i*m.cols+j] = v
+}
+
+func (m Matrix[T]) Row(i int) Vector[T] {
+	return append(Vector[T](nil), m.data[i*m.cols:(i+1)*m.cols]...)
+}
+
+func (m Matrix[T]) Col(j int) Vector[T] {
+	col := make(Vector[T], m.rows)
+	for i := range col {
+		col[i] = m.At(i, j)
+	}
+	return col
+}
+
+func (m Matrix[T]) sameShape(o Matrix[T]) error {
+	if m.rows != o.rows || m.cols != o.cols {
+		return fmt.Errorf("%w: %dx%d and %dx%d", ErrDimensionMismatch, m.rows, m.cols, o.rows, o.cols)
+	}
+	return nil
+}
+
+func (m Matrix[T]) Add(o Matrix[T]) (Matrix[T], error) {
+	if err := m.sameShape(o); err != nil {
+		return Matrix[T]{}, err
+	}
+	sum := NewMatrix[T](m.rows, m.cols)
+	for i := range m.data {
+		sum.data[i] = calculate.Add(m.data[i], o.data[i])
+	}
+	return sum, nil
+}
+
+func (m Matrix[T]) Sub(o Matrix[T]) (Matrix[T], error) {
+	if err := m.sameShape(o); err != nil {
+		return Matrix[T]{}, err
+	}
+	diff := NewMatrix[T](m.rows, m.cols)
+	for i := range m.data {
+		diff.data[i] = m.data[i] - o.data[i]
+	}
+	return diff, nil
+}
+
+// Scale multiplies every entry by k with calculate.Multiply, the generic
+// form of Multiplay.
+func (m Matrix[T]) Scale(k T) Matrix[T] {
+	scaled := NewMatrix[T](m.rows, m.cols)
+	for i, x := range m.data {
+		scaled.data[i] = calculate.Multiply(x, k)
+	}
+	return scaled
+}
+
+func (m Matrix[T]) Mul(o Matrix[T]) (Matrix[T], error) {
+	if m.cols != o.rows {
+		return Matrix[T]{}, fmt.Errorf("%w: %dx%d times %dx%d", ErrDimensionMismatch, m.rows, m.cols, o.rows, o.cols)
+	}
+	product := NewMatrix[T](m.rows, o.cols)
+	for i := 0; i < m.rows; i++ {
+		for k := 0; k < m.cols; k++ {
+			a := m.At(i, k)
+			for j := 0; j < o.cols; j++ {
+				c := &product.data[i*o.cols+j]
+				*c = calculate.Add(*c, calculate.Multiply(a, o.At(k, j)))
+			}
+		}
+	}
+	return product, nil
+}
+
+func (m Matrix[T]) MulVec(v Vector[T]) (Vector[T], error) {
+	if m.cols != len(v) {
+		return nil, fmt.Errorf("%w: %dx%d times %d", ErrDimensionMismatch, m.rows, m.cols, len(v))
+	}
+	product := make(Vector[T], m.rows)
+	for i := range product {
+		product[i], _ = m.Row(i).Dot(v)
+	}
+	return product, nil
+}
+
+func (m Matrix[T]) Transpose() Matrix[T] {
+	t := NewMatrix[T](m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.data[j*m.rows+i] = m.At(i, j)
+		}
+	}
+	return t
+}
+
+// Float64 converts the elements of m to float64.
+func (m Matrix[T]) Float64() Matrix[float64] {
+	f := NewMatrix[float64](m.rows, m.cols)
+	for i, x := range m.data {
+		f.data[i] = float64(x)
+	}
+	return f
+}
+
+func (m Matrix[T]) Det() (float64, error) {
+	lu, err := Decompose(m)
+	if errors.Is(err, ErrSingular) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return lu.Det(), nil
+}
+
+func (m Matrix[T]) Inverse() (Matrix[float64], error) {
+	lu, err := Decompose(m)
+	if err != nil {
+		return Matrix[float64]{}, err
+	}
+	return lu.Inverse(), nil
+}
+
+// Solve returns x such that m·x = b.
+func (m Matrix[T]) Solve(b Vector[T]) (Vector[float64], error) {
+	lu, err := Decompose(m)
+	if err != nil {
+		return nil, err
+	}
+	return lu.Solve(toFloat(b))
+}
+
+// String prints one bracketed row per line with right-aligned columns:
+//
+//	[ 1 -2 ]
+//	[ 3 10 ]
+func (m Matrix[T]) String() string {
+	cells := make([]string, len(m.data))
+	widths := make([]int, m.cols)
+	for i, x := range m.data {
+		cells[i] = fmt.Sprint(x)
+		widths[i%m.cols] = max(widths[i%m.cols], len(cells[i]))
+	}
+
+	var b strings.Builder
+	for i := 0; i < m.rows; i++ {
+		b.WriteString("[")
+		for j := 0; j < m.cols; j++ {
+			fmt.Fprintf(&b, " %*s", widths[j], cells[i*m.cols+j])
+		}
+		b.WriteString(" ]\n")
+	}
+	return b.String()
+}
+
+func toFloat[T calculate.Real](v Vector[T]) Vector[float64] {
+	f := make(Vector[float64], len(v))
+	for i, x := range v {
+		f[i] = float64(x)
+	}
+	return f
+}
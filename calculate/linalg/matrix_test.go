@@ -0,0 +1,195 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func mustRows[T int | float64](t *testing.T, rows [][]T) Matrix[T] {
+	t.Helper()
+	m, err := FromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMatrix_Arithmetic(t *testing.T) {
+	a := mustRows(t, [][]int{{1, 2}, {3, 4}})
+	b := mustRows(t, [][]int{{0, 1}, {-1, 5}})
+	c := mustRows(t, [][]int{{1, 2, 3}, {4, 5, 6}})
+
+	sum, _ := a.Add(b)
+	diff, _ := a.Sub(b)
+	product, _ := a.Mul(c)
+	vec, _ := a.MulVec(Vector[int]{1, -1})
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"add", sum.String(), "[ 1 3 ]\n[ 2 9 ]\n"},
+		{"sub", diff.String(), "[ 1  1 ]\n[ 4 -1 ]\n"},
+		{"scale", a.Scale(3).String(), "[ 3  6 ]\n[ 9 12 ]\n"},
+		{"mul", product.String(), "[  9 12 15 ]\n[ 19 26 33 ]\n"},
+		{"transpose", c.Transpose().String(), "[ 1 4 ]\n[ 2 5 ]\n[ 3 6 ]\n"},
+		{"mul vec", vec.String(), "(-1, -1)"},
+		{"identity", Identity[int](2).String(), "[ 1 0 ]\n[ 0 1 ]\n"},
+		{"row", c.Row(1).String(), "(4, 5, 6)"},
+		{"col", c.Col(2).String(), "(3, 6)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != tt.expected {
+				t.Errorf("got\n%s\nexpected\n%s", tt.result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatrix_DimensionMismatch(t *testing.T) {
+	a := mustRows(t, [][]int{{1, 2}, {3, 4}})
+	c := mustRows(t, [][]int{{1, 2, 3}, {4, 5, 6}})
+
+	if _, err := a.Add(c); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Add error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := c.Mul(a); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Mul error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := a.MulVec(Vector[int]{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("MulVec error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := FromRows([][]int{{1, 2}, {3}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("FromRows error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := c.Det(); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("Det error = %v; expected %v", err, ErrNotSquare)
+	}
+	if _, err := (Vector[int]{1, 2}).Dot(Vector[int]{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Dot error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+}
+
+func TestMatrix_Det(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     [][]float64
+		expected float64
+	}{
+		{"identity", [][]float64{{1, 0}, {0, 1}}, 1},
+		{"2x2", [][]float64{{4, 3}, {6, 3}}, -6},
+		{"needs pivoting", [][]float64{{0, 1}, {1, 0}}, -1},
+		{"3x3", [][]float64{{2, -3, 1}, {2, 0, -1}, {1, 4, 5}}, 49},
+		{"singular", [][]float64{{1, 2}, {2, 4}}, 0},
+		{"tiny row", [][]float64{{1e-20, 0}, {0, 1}}, 1e-20},
+		{"tiny row pivoted", [][]float64{{0, 1}, {1e-20, 0}}, -1e-20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			det, err := mustRows(t, tt.rows).Det()
+			if err != nil || math.Abs(det-tt.expected) > 1e-9*math.Max(1, math.Abs(tt.expected)) || (det == 0) != (tt.expected == 0) {
+				t.Errorf("Det() = %v, %v; expected %v", det, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatrix_InverseAndSolve(t *testing.T) {
+	a := mustRows(t, [][]int{{2, 1, 1}, {1, 3, 2}, {1, 0, 0}})
+
+	inv, err := a.Inverse()
+	if err != nil {
+		t.Fatalf("Inverse error: %v", err)
+	}
+	product, _ := a.Float64().Mul(inv)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(product.At(i, j)-want) > 1e-9 {
+				t.Fatalf("A·A⁻¹ =\n%s\nexpected identity", product)
+			}
+		}
+	}
+
+	x, err := a.Solve(Vector[int]{4, 5, 6})
+	if err != nil {
+		t.Fatalf("Solve error: %v", err)
+	}
+	expected := Vector[float64]{6, 15, -23}
+	for i := range x {
+		if math.Abs(x[i]-expected[i]) > 1e-9 {
+			t.Errorf("Solve = %v; expected %v", x, expected)
+			break
+		}
+	}
+
+	singular := mustRows(t, [][]float64{{1, 2}, {2, 4}})
+	if _, err := singular.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("Inverse error = %v; expected %v", err, ErrSingular)
+	}
+	if _, err := singular.Solve(Vector[float64]{1, 2}); !errors.Is(err, ErrSingular) {
+		t.Errorf("Solve error = %v; expected %v", err, ErrSingular)
+	}
+}
+
+func TestMatrix_RREF(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     [][]float64
+		expected [][]float64
+		pivots   []int
+	}{
+		{"full rank", [][]float64{{2, 1, 5}, {1, -1, 1}}, [][]float64{{1, 0, 2}, {0, 1, 1}}, []int{0, 1}},
+		{"dependent rows", [][]float64{{1, 2, 3}, {2, 4, 6}}, [][]float64{{1, 2, 3}, {0, 0, 0}}, []int{0}},
+		{"skipped column", [][]float64{{0, 1, 2}, {0, 2, 5}}, [][]float64{{0, 1, 0}, {0, 0, 1}}, []int{1, 2}},
+		{"zero", [][]float64{{0, 0}, {0, 0}}, [][]float64{{0, 0}, {0, 0}}, nil},
+		{"tiny column", [][]float64{{0x1p-50, 1}}, [][]float64{{1, 0x1p50}}, []int{0}},
+		{"noise below column scale", [][]float64{{1, 1, 1e-20}, {1, 1, 2e-20}}, [][]float64{{1, 1, 0}, {0, 0, 1}}, []int{0, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, pivots := mustRows(t, tt.rows).RREF()
+			for i, row := range tt.expected {
+				for j, want := range row {
+					if math.Abs(r.At(i, j)-want) > 1e-12 {
+						t.Fatalf("RREF =\n%sexpected %v", r, tt.expected)
+					}
+				}
+			}
+			if !slices.Equal(pivots, tt.pivots) {
+				t.Errorf("pivots = %v; expected %v", pivots, tt.pivots)
+			}
+			if rank := mustRows(t, tt.rows).Rank(); rank != len(tt.pivots) {
+				t.Errorf("Rank = %d; expected %d", rank, len(tt.pivots))
+			}
+		})
+	}
+}
+
+func TestVector(t *testing.T) {
+	v := Vector[float64]{3, 4}
+	w := Vector[float64]{1, -2}
+
+	sum, _ := v.Add(w)
+	diff, _ := v.Sub(w)
+	dot, _ := v.Dot(w)
+	if sum.String() != "(4, 2)" || diff.String() != "(2, 6)" || dot != -5 {
+		t.Errorf("Add = %v, Sub = %v, Dot = %v", sum, diff, dot)
+	}
+	if _, err := v.Sub(Vector[float64]{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Sub error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if v.Norm() != 5 || v.Scale(0.5).String() != "(1.5, 2)" {
+		t.Errorf("Norm = %v, Scale = %v", v.Norm(), v.Scale(0.5))
+	}
+}
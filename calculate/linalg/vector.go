@@ -0,0 +1,71 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golang-lessons/calculate"
+)
+
+type Vector[T calculate.Real] []T
+
+func (v Vector[T]) Add(w Vector[T]) (Vector[T], error) {
+	if len(v) != len(w) {
+		return nil, fmt.Errorf("%w: %d and %d", ErrDimensionMismatch, len(v), len(w))
+	}
+	sum := make(Vector[T], len(v))
+	for i := range v {
+		sum[i] = calculate.Add(v[i], w[i])
+	}
+	return sum, nil
+}
+
+func (v Vector[T]) Sub(w Vector[T]) (Vector[T], error) {
+	if len(v) != len(w) {
+		return nil, fmt.Errorf("%w: %d and %d", ErrDimensionMismatch, len(v), len(w))
+	}
+	diff := make(Vector[T], len(v))
+	for i := range v {
+		diff[i] = v[i] - w[i]
+	}
+	return diff, nil
+}
+
+// Scale multiplies every component by k with calculate.Multiply, the
+// generic form of Multiplay.
+func (v Vector[T]) Scale(k T) Vector[T] {
+	scaled := make(Vector[T], len(v))
+	for i, x := range v {
+		scaled[i] = calculate.Multiply(x, k)
+	}
+	return scaled
+}
+
+func (v Vector[T]) Dot(w Vector[T]) (T, error) {
+	if len(v) != len(w) {
+		return 0, fmt.Errorf("%w: %d and %d", ErrDimensionMismatch, len(v), len(w))
+	}
+	var dot T
+	for i := range v {
+		dot = calculate.Add(dot, calculate.Multiply(v[i], w[i]))
+	}
+	return dot, nil
+}
+
+// Norm returns the Euclidean length of v.
+func (v Vector[T]) Norm() float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
+
+func (v Vector[T]) String() string {
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = fmt.Sprint(x)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,343 @@
+package calculate
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type parser struct {
+	lex      *lexer
+	tok      token
+	prev     token // the token consumed last
+	caretXor bool
+}
+
+// Parse turns an infix expression into a syntax tree. Operators follow the
+// usual precedence: ^ (or **) binds tightest and is right-associative, then
+// unary minus and ~, then * / %, then + -, then the bitwise << >>, &, and |
+// in that order. A number directly followed by a name or a parenthesis is
+// multiplied by it, so 3x^2 reads as 3 * x^2, and so is a closing
+// parenthesis followed by a name, number or parenthesis, as in
+// (1 - x)(x + 2) and (2 + x)3. A line of the form
+// "name = expr" parses to an *AssignStmt and "name(a, b) = expr" to a
+// *FuncDef.
+func Parse(src string) (Node, error) {
+	return parse(src, false)
+}
+
+// parse is Parse with ^ optionally meaning xor, placed between & and |;
+// ** is then the only power operator.
+func parse(src string, caretXor bool) (Node, error) {
+	p := &parser{lex: newLexer(src), caretXor: caretXor}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	n, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return n, nil
+}
+
+func (p *parser) next() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.prev, p.tok = p.tok, tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return &SyntaxError{Column: p.tok.column, Msg: "unexpected end of input"}
+	}
+	return &SyntaxError{Column: p.tok.column, Msg: "unexpected " + strconv.Quote(p.tok.text)}
+}
+
+func (p *parser) statement() (Node, error) {
+	x, err := p.expr()
+	if err != nil || p.tok.kind != tokAssign {
+		return x, err
+	}
+	assign := p.tok
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	y, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := x.(type) {
+	case *Ident:
+		return &AssignStmt{Column: x.Column, Name: x.Name, X: y}, nil
+	case *CallExpr:
+		params := make([]string, len(x.Args))
+		seen := make(map[string]bool)
+		for i, arg := range x.Args {
+			param, ok := arg.(*Ident)
+			if !ok {
+				return nil, &SyntaxError{Column: arg.Pos(), Msg: "parameter must be a name, not " + arg.String()}
+			}
+			if seen[param.Name] {
+				return nil, &SyntaxError{Column: arg.Pos(), Msg: "duplicate parameter " + param.Name}
+			}
+			seen[param.Name] = true
+			params[i] = param.Name
+		}
+		return &FuncDef{Column: x.Column, Name: x.Name, Params: params, Body: y}, nil
+	}
+	return nil, &SyntaxError{Column: assign.column, Msg: "cannot assign to " + x.String()}
+}
+
+var (
+	orOps       = map[tokenKind]Op{tokPipe: OpOr}
+	xorOps      = map[tokenKind]Op{tokCaret: OpXor}
+	andOps      = map[tokenKind]Op{tokAmp: OpAnd}
+	shiftOps    = map[tokenKind]Op{tokShl: OpShl, tokShr: OpShr}
+	additiveOps = map[tokenKind]Op{tokPlus: OpAdd, tokMinus: OpSub}
+)
+
+func (p *parser) expr() (Node, error) {
+	return p.leftAssoc(orOps, p.xor)
+}
+
+func (p *parser) xor() (Node, error) {
+	if !p.caretXor {
+		return p.and()
+	}
+	return p.leftAssoc(xorOps, p.and)
+}
+
+func (p *parser) and() (Node, error) {
+	return p.leftAssoc(andOps, p.shift)
+}
+
+func (p *parser) shift() (Node, error) {
+	return p.leftAssoc(shiftOps, p.additive)
+}
+
+func (p *parser) additive() (Node, error) {
+	return p.leftAssoc(additiveOps, p.term)
+}
+
+// leftAssoc parses a left-associative chain of the operators in ops whose
+// operands are parsed by operand.
+func (p *parser) leftAssoc(ops map[tokenKind]Op, operand func() (Node, error)) (Node, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := ops[p.tok.kind]
+		if !ok {
+			return x, nil
+		}
+		column := p.tok.column
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x = &BinaryExpr{Column: column, Op: op, X: x, Y: y}
+	}
+}
+
+func (p *parser) term() (Node, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op Op
+		switch p.tok.kind {
+		case tokStar:
+			op = OpMul
+		case tokSlash:
+			op = OpDiv
+		case tokPercent:
+			op = OpMod
+		case tokIdent, tokLParen, tokNumber:
+			if p.prev.kind != tokRParen && (p.tok.kind == tokNumber || !endsWithNumber(x)) {
+				return x, nil
+			}
+			y, err := p.unary()
+			if err != nil {
+				return nil, err
+			}
+			x = &BinaryExpr{Column: y.Pos(), Op: OpMul, X: x, Y: y}
+			continue
+		default:
+			return x, nil
+		}
+		column := p.tok.column
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = &BinaryExpr{Column: column, Op: op, X: x, Y: y}
+	}
+}
+
+// endsWithNumber reports whether n's rightmost operand is a number literal,
+// which is where implicit multiplication applies.
+func endsWithNumber(n Node) bool {
+	switch n := n.(type) {
+	case *NumberLit:
+		return true
+	case *UnaryExpr:
+		return endsWithNumber(n.X)
+	case *BinaryExpr:
+		return n.Op != OpPow && endsWithNumber(n.Y)
+	}
+	return false
+}
+
+var unaryOps = map[tokenKind]Op{tokPlus: OpAdd, tokMinus: OpSub, tokTilde: OpNot}
+
+func (p *parser) unary() (Node, error) {
+	op, ok := unaryOps[p.tok.kind]
+	if !ok {
+		return p.power()
+	}
+	column := p.tok.column
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return &UnaryExpr{Column: column, Op: op, X: x}, nil
+}
+
+func (p *parser) power() (Node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokStarStar && (p.tok.kind != tokCaret || p.caretXor) {
+		return x, nil
+	}
+	column := p.tok.column
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	// The exponent may carry its own sign: 2^-1.
+	y, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return &BinaryExpr{Column: column, Op: OpPow, X: x, Y: y}, nil
+}
+
+func (p *parser) primary() (Node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		v, err := parseNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return &NumberLit{Column: tok.column, Text: tok.text, Value: v}, nil
+	case tokIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokLParen {
+			return p.call(tok)
+		}
+		return &Ident{Column: tok.column, Name: tok.text}, nil
+	case tokLParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			if p.tok.kind == tokEOF {
+				return nil, &SyntaxError{Column: tok.column, Msg: "unclosed parenthesis"}
+			}
+			return nil, p.unexpected()
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	return nil, p.unexpected()
+}
+
+// call parses the argument list following name; the current token is "(".
+func (p *parser) call(name token) (Node, error) {
+	open := p.tok
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	n := &CallExpr{Column: name.column, Name: name.text}
+	for p.tok.kind != tokRParen {
+		if len(n.Args) > 0 {
+			if p.tok.kind != tokComma {
+				if p.tok.kind == tokEOF {
+					return nil, &SyntaxError{Column: open.column, Msg: "unclosed parenthesis"}
+				}
+				return nil, p.unexpected()
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		n.Args = append(n.Args, arg)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func parseNumber(tok token) (Value, error) {
+	if basePrefix(tok.text) != 0 {
+		i, err := ParseInt(tok.text)
+		if errors.Is(err, ErrOverflow) {
+			return Value{}, &SyntaxError{Column: tok.column, Msg: "integer out of range " + strconv.Quote(tok.text)}
+		}
+		if err != nil {
+			return Value{}, &SyntaxError{Column: tok.column, Msg: "malformed number " + strconv.Quote(tok.text)}
+		}
+		return IntValue(i), nil
+	}
+	if !strings.ContainsAny(tok.text, ".eE") {
+		i, err := strconv.Atoi(tok.text)
+		if err == nil {
+			return IntValue(i), nil
+		}
+		if !errors.Is(err, strconv.ErrRange) {
+			return Value{}, &SyntaxError{Column: tok.column, Msg: "malformed number " + strconv.Quote(tok.text)}
+		}
+		// Too large for int: fall back to a float.
+	}
+	f, err := strconv.ParseFloat(tok.text, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return Value{}, &SyntaxError{Column: tok.column, Msg: "malformed number " + strconv.Quote(tok.text)}
+	}
+	return FloatValue(f), nil
+}
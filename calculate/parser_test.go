@@ -0,0 +1,102 @@
+package calculate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"number", "42", "42"},
+		{"precedence", "1+2*3", "1 + 2 * 3"},
+		{"parentheses kept", "(1+2)*3", "(1 + 2) * 3"},
+		{"redundant parentheses", "((1))+(2*3)", "1 + 2 * 3"},
+		{"left associative", "1-(2-3)", "1 - (2 - 3)"},
+		{"right associative power", "2^3^2", "2^3^2"},
+		{"power grouping", "(2^3)^2", "(2^3)^2"},
+		{"unary minus", "-2^2", "-2^2"},
+		{"negative base", "(-2)^2", "(-2)^2"},
+		{"negative exponent", "2^-1", "2^(-1)"},
+		{"identifiers", "pi * r^2", "pi * r^2"},
+		{"float", "1.5e3 % 7", "1.5e3 % 7"},
+		{"assignment", "x=(1+2)", "x = 1 + 2"},
+		{"call", "max( 1,2 ,x^2)", "max(1, 2, x^2)"},
+		{"call without args", "f()", "f()"},
+		{"function definition", "f(x,y)=x*y+1", "f(x, y) = x * y + 1"},
+		{"implicit multiplication", "3x^2 - 2x", "3 * x^2 - 2 * x"},
+		{"implicit with parentheses", "2(x + 1)", "2 * (x + 1)"},
+		{"implicit after sign", "-2pi", "-2 * pi"},
+		{"hex literal", "0xFF & x", "0xFF & x"},
+		{"bitwise precedence", "1 | 2 & 3 << 1 + 1", "1 | 2 & 3 << 1 + 1"},
+		{"shift grouping", "(1 | 2) << 3", "(1 | 2) << 3"},
+		{"bitwise not", "~(x & 1)", "~(x & 1)"},
+		{"double star power", "2**3**2", "2^3^2"},
+		{"zero times x", "0x", "0 * x"},
+		{"product of groups", "(1 - x)(x + 2)", "(1 - x) * (x + 2)"},
+		{"groups with names", "(x - a)(x + 2)", "(x - a) * (x + 2)"},
+		{"number after group", "(2+x)3", "(2 + x) * 3"},
+		{"name after group", "(x + 1)y", "(x + 1) * y"},
+		{"after call", "sin(x)cos(x)", "sin(x) * cos(x)"},
+		{"after power of group", "2^(1 + 1)(x)", "2^(1 + 1) * x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if result := n.String(); result != tt.expected {
+				t.Errorf("Parse(%q).String() = %q; expected %q",
+					tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		column int
+	}{
+		{"empty", "", 1},
+		{"trailing operator", "1 +", 4},
+		{"unexpected character", "2 $ 3", 3},
+		{"unclosed parenthesis", "(1 + 2", 1},
+		{"extra parenthesis", "1 + 2)", 6},
+		{"missing operand", "* 2", 1},
+		{"lone dot", "1 + .", 5},
+		{"cyrillic column", "ж + )", 5},
+		{"assign to number", "1 = 2", 3},
+		{"chained assignment", "x = y = 2", 7},
+		{"unclosed call", "f(1, 2", 2},
+		{"missing comma", "f(1 2)", 5},
+		{"no implicit after name", "x y", 3},
+		{"no implicit between numbers", "2 3", 3},
+		{"no implicit after exponent", "(x + 1)^2(x - 1)", 10},
+		{"number parameter", "f(1) = 2", 3},
+		{"duplicate parameter", "f(x, x) = 2", 6},
+		{"bad binary digit", "0b102", 1},
+		{"hex out of range", "1 + 0x1_0000_0000_0000_0000", 5},
+		{"single angle", "1 < 2", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v; expected *SyntaxError", tt.input, err)
+			}
+			if syntaxErr.Column != tt.column {
+				t.Errorf("Parse(%q) column = %d; expected %d",
+					tt.input, syntaxErr.Column, tt.column)
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNotPolynomial  = errors.New("not a polynomial")
+	ErrNoSignChange   = errors.New("function has the same sign at both ends of the interval")
+	ErrNoConvergence  = errors.New("root finding did not converge")
+	ErrZeroDerivative = errors.New("derivative vanished")
+	ErrDegreeTooLarge = errors.New("polynomial degree too large")
+)
+
+// MaxPolynomialDegree bounds the degree Pow builds, so that "(x+1)^1e9"
+// fails instead of allocating a billion coefficients.
+const MaxPolynomialDegree = 1 << 12
+
+// Polynomial holds coefficients in ascending order of power: p[i] is the
+// coefficient of x^i. Polynomial{1, -2, 3} is 3x^2 - 2x + 1.
+type Polynomial []float64
+
+// ParsePolynomial parses an expression in at most one variable such as
+// "3x^2 - 2x + 1" or "(x - 1)(x + 2)". Exponents must be non-negative
+// integer constants and division is only allowed by constants.
+func ParsePolynomial(s string) (Polynomial, error) {
+	n, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	var variable string
+	return toPolynomial(n, &variable)
+}
+
+func toPolynomial(n Node, variable *string) (Polynomial, error) {
+	switch n := n.(type) {
+	case *NumberLit:
+		return Polynomial{n.Value.Float64()}, nil
+	case *Ident:
+		// Coefficients are real, so i is an ordinary variable here.
+		if c, ok := constants[n.Name]; ok && c.kind != KindComplex {
+			return Polynomial{c.Float64()}, nil
+		}
+		if *variable != "" && *variable != n.Name {
+			return nil, &EvalError{Column: n.Column,
+				Err: fmt.Errorf("%w: second variable %s besides %s", ErrNotPolynomial, n.Name, *variable)}
+		}
+		*variable = n.Name
+		return Polynomial{0, 1}, nil
+	case *UnaryExpr:
+		if n.Op == OpNot {
+			return nil, &EvalError{Column: n.Column,
+				Err: fmt.Errorf("%w: unsupported %v", ErrNotPolynomial, n.Op)}
+		}
+		x, err := toPolynomial(n.X, variable)
+		if err != nil || n.Op == OpAdd {
+			return x, err
+		}
+		return x.Scale(-1), nil
+	case *BinaryExpr:
+		x, err := toPolynomial(n.X, variable)
+		if err != nil {
+			return nil, err
+		}
+		y, err := toPolynomial(n.Y, variable)
+		if err != nil {
+			return nil, err
+		}
+		switch n.Op {
+		case OpAdd:
+			return x.Add(y), nil
+		case OpSub:
+			return x.Sub(y), nil
+		case OpMul:
+			return x.Mul(y), nil
+		case OpDiv:
+			if y.Degree() == 0 {
+				return x.Scale(1 / y[0]), nil
+			}
+			if y.Degree() < 0 {
+				return nil, &EvalError{Column: n.Column, Err: ErrDivisionByZero}
+			}
+		case OpPow:
+			if k := y.constant(); y.Degree() <= 0 && k >= 0 && k == math.Trunc(k) {
+				if x.Degree() <= 0 {
+					return Polynomial{math.Pow(x.constant(), k)}.trim(), nil
+				}
+				if k > MaxPolynomialDegree {
+					return nil, &EvalError{Column: n.Column,
+						Err: fmt.Errorf("%w: power %v", ErrDegreeTooLarge, k)}
+				}
+				p, err := x.Pow(int(k))
+				if err != nil {
+					return nil, &EvalError{Column: n.Column, Err: err}
+				}
+				return p, nil
+			}
+		}
+		return nil, &EvalError{Column: n.Column,
+			Err: fmt.Errorf("%w: unsupported %v", ErrNotPolynomial, n.Op)}
+	}
+	return nil, &EvalError{Column: n.Pos(), Err: fmt.Errorf("%w: %v", ErrNotPolynomial, n)}
+}
+
+// trim drops zero leading coefficients.
+func (p Polynomial) trim() Polynomial {
+	for len(p) > 0 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+// Degree returns the degree of p, or -1 for the zero polynomial.
+func (p Polynomial) Degree() int {
+	return len(p.trim()) - 1
+}
+
+func (p Polynomial) constant() float64 {
+	if len(p) == 0 {
+		return 0
+	}
+	return p[0]
+}
+
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	sum := make(Polynomial, max(len(p), len(q)))
+	copy(sum, p)
+	for i, c := range q {
+		sum[i] += c
+	}
+	return sum.trim()
+}
+
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	return p.Add(q.Scale(-1))
+}
+
+func (p Polynomial) Scale(k float64) Polynomial {
+	scaled := make(Polynomial, len(p))
+	for i, c := range p {
+		scaled[i] = c * k
+	}
+	return scaled.trim()
+}
+
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	p, q = p.trim(), q.trim()
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	product := make(Polynomial, len(p)+len(q)-1)
+	for i, a := range p {
+		for j, b := range q {
+			product[i+j] += a * b
+		}
+	}
+	return product.trim()
+}
+
+// Pow returns p^n for n >= 0 by repeated squaring. Results above
+// MaxPolynomialDegree return ErrDegreeTooLarge.
+func (p Polynomial) Pow(n int) (Polynomial, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: negative power %d", ErrNotPolynomial, n)
+	}
+	if d := p.Degree(); d > 0 && n > MaxPolynomialDegree/d {
+		return nil, fmt.Errorf("%w: degree %d to the %d", ErrDegreeTooLarge, d, n)
+	}
+	result := Polynomial{1}
+	for base := p; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = result.Mul(base)
+		}
+		if n > 1 {
+			base = base.Mul(base)
+		}
+	}
+	return result, nil
+}
+
+// DivMod divides p by d using long division, so p = q·d + r with
+// deg r < deg d.
+func (p Polynomial) DivMod(d Polynomial) (q, r Polynomial, err error) {
+	d = d.trim()
+	if len(d) == 0 {
+		return nil, nil, ErrDivisionByZero
+	}
+	r = append(Polynomial(nil), p.trim()...)
+	if len(r) < len(d) {
+		return nil, r, nil
+	}
+	q = make(Polynomial, len(r)-len(d)+1)
+	lead := d[len(d)-1]
+	for i := len(q) - 1; i >= 0; i-- {
+		c := r[i+len(d)-1] / lead
+		q[i] = c
+		for j, dc := range d {
+			r[i+j] -= c * dc
+		}
+		r[i+len(d)-1] = 0
+	}
+	return q.trim(), r.trim(), nil
+}
+
+func (p Polynomial) Derivative() Polynomial {
+	if len(p) <= 1 {
+		return nil
+	}
+	d := make(Polynomial, len(p)-1)
+	for i := 1; i < len(p); i++ {
+		d[i-1] = float64(i) * p[i]
+	}
+	return d.trim()
+}
+
+// Integral returns the antiderivative of p with constant term c.
+func (p Polynomial) Integral(c float64) Polynomial {
+	in := make(Polynomial, len(p)+1)
+	in[0] = c
+	for i, a := range p {
+		in[i+1] = a / float64(i+1)
+	}
+	return in.trim()
+}
+
+// Eval evaluates p at x with Horner's scheme.
+func (p Polynomial) Eval(x float64) float64 {
+	var y float64
+	for i := len(p) - 1; i >= 0; i-- {
+		y = y*x + p[i]
+	}
+	return y
+}
+
+func (p Polynomial) String() string {
+	return p.Format("x")
+}
+
+// Format writes p in descending powers of variable: "3x^2 - 2x + 1".
+func (p Polynomial) Format(variable string) string {
+	p = p.trim()
+	if len(p) == 0 {
+		return "0"
+	}
+	var b strings.Builder
+	for i := len(p) - 1; i >= 0; i-- {
+		c := p[i]
+		if c == 0 {
+			continue
+		}
+		switch {
+		case b.Len() == 0 && c < 0:
+			b.WriteString("-")
+		case b.Len() > 0 && c < 0:
+			b.WriteString(" - ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		c = math.Abs(c)
+		if c != 1 || i == 0 {
+			b.WriteString(strconv.FormatFloat(c, 'g', -1, 64))
+		}
+		if i > 0 {
+			b.WriteString(variable)
+		}
+		if i > 1 {
+			b.WriteString("^" + strconv.Itoa(i))
+		}
+	}
+	return b.String()
+}
+
+// termSize returns Σ|c_i|·|x|^i, the size of the terms p(x) sums.
+func (p Polynomial) termSize(x float64) float64 {
+	return p.abs().Eval(math.Abs(x))
+}
+
+func (p Polynomial) abs() Polynomial {
+	abs := make(Polynomial, len(p))
+	for i, c := range p {
+		abs[i] = math.Abs(c)
+	}
+	return abs
+}
+
+// Newton refines x0 towards a root until successive steps differ by less
+// than tol.
+func (p Polynomial) Newton(x0, tol float64, maxIter int) (float64, error) {
+	d := p.Derivative()
+	x := x0
+	for i := 0; i < maxIter; i++ {
+		slope := d.Eval(x)
+		if slope == 0 {
+			return x, ErrZeroDerivative
+		}
+		step := p.Eval(x) / slope
+		x -= step
+		if math.Abs(step) < tol {
+			return x, nil
+		}
+	}
+	return x, ErrNoConvergence
+}
+
+// Bisection finds a root in [a, b], where p must change sign, to within tol.
+func (p Polynomial) Bisection(a, b, tol float64) (float64, error) {
+	fa, fb := p.Eval(a), p.Eval(b)
+	switch {
+	case fa == 0:
+		return a, nil
+	case fb == 0:
+		return b, nil
+	case (fa < 0) == (fb < 0):
+		return 0, ErrNoSignChange
+	}
+	for b-a > tol {
+		mid := a + (b-a)/2
+		if mid == a || mid == b {
+			break // interval is as small as float64 allows
+		}
+		fm := p.Eval(mid)
+		if fm == 0 {
+			return mid, nil
+		}
+		if (fm < 0) == (fa < 0) {
+			a, fa = mid, fm
+		} else {
+			b = mid
+		}
+	}
+	return a + (b-a)/2, nil
+}
+
+// RealRoots returns the distinct real roots of p in ascending order, each
+// to within tol. Between consecutive roots of the derivative p is
+// monotonic, so every such interval holds at most one root that bisection
+// can bracket; roots of even multiplicity sit on the critical points
+// themselves.
+func (p Polynomial) RealRoots(tol float64) []float64 {
+	p = p.trim()
+	switch len(p) {
+	case 0, 1:
+		return nil
+	case 2:
+		return []float64{-p[0] / p[1]}
+	}
+
+	// Cauchy's bound: every root lies strictly inside (-bound, bound).
+	lead, bound := p[len(p)-1], 0.0
+	for _, c := range p {
+		bound = math.Max(bound, math.Abs(c/lead))
+	}
+	bound++
+
+	points := append([]float64{-bound}, p.Derivative().RealRoots(tol)...)
+	points = append(points, bound)
+
+	var roots []float64
+	add := func(x float64) {
+		if len(roots) == 0 || x-roots[len(roots)-1] > tol {
+			roots = append(roots, x)
+		}
+	}
+	for i := 0; i+1 < len(points); i++ {
+		a, b := points[i], points[i+1]
+		// A critical point is a root when p vanishes there up to the
+		// rounding error of the terms summed to evaluate it.
+		if i > 0 && math.Abs(p.Eval(a)) <= 1e-12*p.termSize(a) {
+			add(a)
+			continue
+		}
+		if x, err := p.Bisection(a, b, tol); err == nil && x != b {
+			add(x)
+		}
+	}
+	sort.Float64s(roots)
+	return roots
+}
@@ -0,0 +1,159 @@
+package calculate
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParsePolynomial(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"quadratic", "3x^2 - 2x + 1", "3x^2 - 2x + 1"},
+		{"constant", "7", "7"},
+		{"zero", "x - x", "0"},
+		{"product", "(x - 1)(x + 2)", "x^2 + x - 2"},
+		{"product with leading constant", "(1 - x)(x + 2)", "-x^2 - x + 2"},
+		{"constant after group", "(2 + x)3", "3x + 6"},
+		{"constant to a huge power", "x + 1^1e9", "x + 1"},
+		{"power of sum", "(t + 1)^3", "x^3 + 3x^2 + 3x + 1"},
+		{"division by constant", "(4y^2 + 2) / 2", "2x^2 + 1"},
+		{"negative leading", "-x^3 + 0.5x", "-x^3 + 0.5x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePolynomial(tt.input)
+			if err != nil {
+				t.Fatalf("ParsePolynomial(%q) error: %v", tt.input, err)
+			}
+			if result := p.String(); result != tt.expected {
+				t.Errorf("ParsePolynomial(%q) = %q; expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	for _, input := range []string{"x^y", "1 / x", "x + y", "x^-1", "x^0.5", "sqrt(x)"} {
+		if _, err := ParsePolynomial(input); !errors.Is(err, ErrNotPolynomial) {
+			t.Errorf("ParsePolynomial(%q) error = %v; expected %v", input, err, ErrNotPolynomial)
+		}
+	}
+}
+
+func TestPolynomial_Arithmetic(t *testing.T) {
+	p := Polynomial{1, -2, 3} // 3x^2 - 2x + 1
+	q := Polynomial{-1, 1}    // x - 1
+
+	quo, rem, err := p.DivMod(q)
+	if err != nil {
+		t.Fatalf("DivMod error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		result   Polynomial
+		expected string
+	}{
+		{"add", p.Add(q), "3x^2 - x"},
+		{"sub", p.Sub(p), "0"},
+		{"mul", p.Mul(q), "3x^3 - 5x^2 + 3x - 1"},
+		{"quotient", quo, "3x + 1"},
+		{"remainder", rem, "2"},
+		{"derivative", p.Derivative(), "6x - 2"},
+		{"integral", p.Integral(5), "x^3 - x^2 + x + 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.result.String(); result != tt.expected {
+				t.Errorf("got %q; expected %q", result, tt.expected)
+			}
+		})
+	}
+
+	if y := p.Eval(2); y != 9 {
+		t.Errorf("Eval(2) = %v; expected 9", y)
+	}
+	if _, _, err := p.DivMod(nil); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("DivMod(0) error = %v; expected %v", err, ErrDivisionByZero)
+	}
+	if d := (Polynomial{}).Degree(); d != -1 {
+		t.Errorf("Degree() of zero = %d; expected -1", d)
+	}
+
+	if cube, err := q.Pow(3); err != nil || cube.String() != "x^3 - 3x^2 + 3x - 1" {
+		t.Errorf("Pow(3) = %v, %v; expected x^3 - 3x^2 + 3x - 1", cube, err)
+	}
+	if _, err := q.Pow(1 << 40); !errors.Is(err, ErrDegreeTooLarge) {
+		t.Errorf("Pow(2^40) error = %v; expected %v", err, ErrDegreeTooLarge)
+	}
+	if _, err := ParsePolynomial("(x + 1)^30000000"); !errors.Is(err, ErrDegreeTooLarge) {
+		t.Errorf("ParsePolynomial of a huge power error = %v; expected %v", err, ErrDegreeTooLarge)
+	}
+}
+
+func TestPolynomial_Roots(t *testing.T) {
+	const tol = 1e-10
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []float64
+	}{
+		{"linear", "2x - 3", []float64{1.5}},
+		{"two roots", "x^2 - 5x + 6", []float64{2, 3}},
+		{"no real roots", "x^2 + 1", nil},
+		{"double root", "x^2 - 2x + 1", []float64{1}},
+		{"cubic", "(x + 2)(x - 0.5)(x - 4)", []float64{-2, 0.5, 4}},
+		{"irrational", "x^2 - 2", []float64{-math.Sqrt2, math.Sqrt2}},
+		{"triple root", "(x - 3)^3", []float64{3}},
+		{"quartic", "x^4 - 10x^2 + 9", []float64{-3, -1, 1, 3}},
+		// p(0) = 1e-4 is tiny next to the x^2 coefficient but not a root.
+		{"near miss at critical point", "x^3 - 1e6x^2 + 1e-4", []float64{-1e-5, 1e-5, 1e6}},
+		{"scaled double root", "1e6(x - 2)^2", []float64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePolynomial(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			roots := p.RealRoots(tol)
+			if len(roots) != len(tt.expected) {
+				t.Fatalf("RealRoots(%s) = %v; expected %v", p, roots, tt.expected)
+			}
+			for i := range roots {
+				if math.Abs(roots[i]-tt.expected[i]) > 1e-6 {
+					t.Errorf("RealRoots(%s) = %v; expected %v", p, roots, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestPolynomial_NewtonAndBisection(t *testing.T) {
+	p := Polynomial{-2, 0, 1} // x^2 - 2
+
+	x, err := p.Newton(1, 1e-12, 50)
+	if err != nil || math.Abs(x-math.Sqrt2) > 1e-12 {
+		t.Errorf("Newton = %v, %v; expected %v", x, err, math.Sqrt2)
+	}
+	x, err = p.Bisection(0, 2, 1e-9)
+	if err != nil || math.Abs(x-math.Sqrt2) > 1e-9 {
+		t.Errorf("Bisection = %v, %v; expected %v", x, err, math.Sqrt2)
+	}
+	if _, err := p.Bisection(2, 3, 1e-9); !errors.Is(err, ErrNoSignChange) {
+		t.Errorf("Bisection error = %v; expected %v", err, ErrNoSignChange)
+	}
+	if _, err := p.Newton(0, 1e-12, 50); !errors.Is(err, ErrZeroDerivative) {
+		t.Errorf("Newton error = %v; expected %v", err, ErrZeroDerivative)
+	}
+	if _, err := (Polynomial{1, 0, 1}).Newton(0.5, 1e-12, 20); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Newton error = %v; expected %v", err, ErrNoConvergence)
+	}
+}
@@ -0,0 +1,208 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+var ErrInvalidRational = errors.New("invalid rational")
+
+// Rational is an exact fraction kept in lowest terms with a positive
+// denominator. The zero value is 0. Operations that would not fit an int
+// numerator or denominator return ErrOverflow.
+type Rational struct {
+	num, den int
+}
+
+func NewRational(num, den int) (Rational, error) {
+	if den == 0 {
+		return Rational{}, ErrDivisionByZero
+	}
+	return fromBig(big.NewRat(int64(num), int64(den)))
+}
+
+// fromBig converts an exact big.Rat, which is already normalized.
+func fromBig(r *big.Rat) (Rational, error) {
+	if !r.Num().IsInt64() || !r.Denom().IsInt64() ||
+		r.Num().Int64() < math.MinInt || r.Num().Int64() > math.MaxInt ||
+		r.Denom().Int64() > math.MaxInt {
+		return Rational{}, ErrOverflow
+	}
+	return Rational{num: int(r.Num().Int64()), den: int(r.Denom().Int64())}, nil
+}
+
+func (r Rational) big() *big.Rat {
+	return big.NewRat(int64(r.num), int64(r.Den()))
+}
+
+func (r Rational) Num() int {
+	return r.num
+}
+
+func (r Rational) Den() int {
+	if r.den == 0 {
+		return 1
+	}
+	return r.den
+}
+
+func (r Rational) IsInt() bool {
+	return r.Den() == 1
+}
+
+func (r Rational) Sign() int {
+	switch {
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	}
+	return 0
+}
+
+func (r Rational) Add(s Rational) (Rational, error) {
+	return fromBig(new(big.Rat).Add(r.big(), s.big()))
+}
+
+func (r Rational) Sub(s Rational) (Rational, error) {
+	return fromBig(new(big.Rat).Sub(r.big(), s.big()))
+}
+
+func (r Rational) Mul(s Rational) (Rational, error) {
+	return fromBig(new(big.Rat).Mul(r.big(), s.big()))
+}
+
+func (r Rational) Div(s Rational) (Rational, error) {
+	if s.num == 0 {
+		return Rational{}, ErrDivisionByZero
+	}
+	return fromBig(new(big.Rat).Quo(r.big(), s.big()))
+}
+
+func (r Rational) Neg() (Rational, error) {
+	return fromBig(new(big.Rat).Neg(r.big()))
+}
+
+// Pow returns r^n; a negative n inverts r. Results that cannot fit are
+// rejected before any big arithmetic, so a huge n fails fast.
+func (r Rational) Pow(n int) (Rational, error) {
+	if n < 0 && r.num == 0 {
+		return Rational{}, ErrDivisionByZero
+	}
+	// A b-bit number to the n has at least (b-1)*n+1 bits, more than an
+	// int holds once (b-1)*n exceeds 63.
+	abs := absInt(n)
+	b := max(bits.Len64(absInt(r.num)), bits.Len(uint(r.Den())))
+	if b > 1 && abs > uint64(63/(b-1)) {
+		return Rational{}, ErrOverflow
+	}
+	exp := new(big.Int).SetUint64(abs)
+	num := new(big.Int).Exp(big.NewInt(int64(r.num)), exp, nil)
+	den := new(big.Int).Exp(big.NewInt(int64(r.Den())), exp, nil)
+	if n < 0 {
+		num, den = den, num
+	}
+	return fromBig(new(big.Rat).SetFrac(num, den))
+}
+
+// absInt returns |n| without overflowing for math.MinInt.
+func absInt(n int) uint64 {
+	if n < 0 {
+		return -uint64(n)
+	}
+	return uint64(n)
+}
+
+func (r Rational) Cmp(s Rational) int {
+	return r.big().Cmp(s.big())
+}
+
+func (r Rational) Float64() float64 {
+	f, _ := r.big().Float64()
+	return f
+}
+
+// RationalFromFloat returns the exact value of f, which is always a
+// fraction with a power-of-two denominator.
+func RationalFromFloat(f float64) (Rational, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Rational{}, fmt.Errorf("%w: %v", ErrInvalidRational, f)
+	}
+	return fromBig(new(big.Rat).SetFloat64(f))
+}
+
+// ParseRational accepts fractions ("3/4", "-3/4"), mixed numbers
+// ("1 1/2", "-1 1/2") and decimals ("0.75", "2").
+func ParseRational(s string) (Rational, error) {
+	s = strings.TrimSpace(s)
+	invalid := fmt.Errorf("%w: %q", ErrInvalidRational, s)
+
+	whole, frac, mixed := strings.Cut(s, " ")
+	if !mixed {
+		whole, frac = "", s
+	}
+	numStr, denStr, isFrac := strings.Cut(strings.TrimSpace(frac), "/")
+
+	var r *big.Rat
+	if isFrac {
+		num, ok1 := new(big.Int).SetString(numStr, 10)
+		den, ok2 := new(big.Int).SetString(denStr, 10)
+		if !ok1 || !ok2 || mixed && (num.Sign() < 0 || strings.HasPrefix(numStr, "+")) {
+			return Rational{}, invalid
+		}
+		if den.Sign() == 0 {
+			return Rational{}, ErrDivisionByZero
+		}
+		r = new(big.Rat).SetFrac(num, den)
+	} else {
+		if mixed {
+			return Rational{}, invalid
+		}
+		d, err := ParseDecimal(frac)
+		if err != nil {
+			return Rational{}, invalid
+		}
+		r = new(big.Rat).SetFrac(d.unscaled(), pow10(d.scale))
+	}
+
+	if mixed {
+		w, ok := new(big.Int).SetString(whole, 10)
+		if !ok {
+			return Rational{}, invalid
+		}
+		if strings.HasPrefix(whole, "-") {
+			r.Neg(r)
+		}
+		r.Add(r, new(big.Rat).SetInt(w))
+	}
+	return fromBig(r)
+}
+
+func (r Rational) String() string {
+	if r.IsInt() {
+		return fmt.Sprint(r.num)
+	}
+	return fmt.Sprintf("%d/%d", r.num, r.den)
+}
+
+// Mixed formats r as a mixed number: "1 1/2", "-2 3/4", "1/3", "5".
+func (r Rational) Mixed() string {
+	if r.IsInt() || -r.den < r.num && r.num < r.den {
+		return r.String()
+	}
+	whole, rem := r.num/r.den, r.num%r.den
+	if rem < 0 {
+		rem = -rem
+	}
+	return fmt.Sprintf("%d %d/%d", whole, rem, r.den)
+}
+
+// DecimalString formats r with prec digits after the point, rounding the
+// last digit half away from zero.
+func (r Rational) DecimalString(prec int) string {
+	return r.big().FloatString(prec)
+}
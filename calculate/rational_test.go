@@ -0,0 +1,152 @@
+package calculate
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseRational(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		mixed    string
+	}{
+		{"fraction", "3/4", "3/4", "3/4"},
+		{"normalized", "6/-8", "-3/4", "-3/4"},
+		{"whole", "10/5", "2", "2"},
+		{"mixed number", "1 1/2", "3/2", "1 1/2"},
+		{"negative mixed", "-2 3/4", "-11/4", "-2 3/4"},
+		{"decimal", "0.75", "3/4", "3/4"},
+		{"negative decimal", "-1.125", "-9/8", "-1 1/8"},
+		{"integer", "42", "42", "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRational(tt.input)
+			if err != nil {
+				t.Fatalf("ParseRational(%q) error: %v", tt.input, err)
+			}
+			if r.String() != tt.expected || r.Mixed() != tt.mixed {
+				t.Errorf("ParseRational(%q) = %s (%s); expected %s (%s)",
+					tt.input, r, r.Mixed(), tt.expected, tt.mixed)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "1/", "a/b", "1 -1/2", "1 2", "1/2/3"} {
+		if _, err := ParseRational(input); !errors.Is(err, ErrInvalidRational) {
+			t.Errorf("ParseRational(%q) error = %v; expected %v", input, err, ErrInvalidRational)
+		}
+	}
+	if _, err := ParseRational("1/0"); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("ParseRational(%q) error = %v; expected %v", "1/0", err, ErrDivisionByZero)
+	}
+}
+
+func TestRational_Arithmetic(t *testing.T) {
+	r := func(num, den int) Rational {
+		v, err := NewRational(num, den)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	str := func(v Rational, err error) string {
+		if err != nil {
+			return err.Error()
+		}
+		return v.String()
+	}
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"add", str(r(1, 2).Add(r(1, 3))), "5/6"},
+		{"sub", str(r(1, 2).Sub(r(3, 4))), "-1/4"},
+		{"mul", str(r(2, 3).Mul(r(9, 4))), "3/2"},
+		{"div", str(r(2, 3).Div(r(-4, 9))), "-3/2"},
+		{"div by zero", str(r(2, 3).Div(Rational{})), ErrDivisionByZero.Error()},
+		{"pow", str(r(-2, 3).Pow(3)), "-8/27"},
+		{"negative pow", str(r(2, 3).Pow(-2)), "9/4"},
+		{"pow of one", str(r(1, 1).Pow(1 << 40)), "1"},
+		{"huge pow fails fast", str(r(3, 2).Pow(30000000)), ErrOverflow.Error()},
+		{"huge negative pow", str(r(2, 1).Pow(-30000000)), ErrOverflow.Error()},
+		{"pow to min int", str(r(-2, 1).Pow(63)), "-9223372036854775808"},
+		{"zero value", str(Rational{}.Add(r(1, 5))), "1/5"},
+		{"overflow", str(r(math.MaxInt, 1).Add(r(1, 1))), ErrOverflow.Error()},
+		{"large intermediates", str(r(math.MaxInt, 2).Mul(r(2, math.MaxInt))), "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != tt.expected {
+				t.Errorf("got %s; expected %s", tt.result, tt.expected)
+			}
+		})
+	}
+
+	if r(1, 3).Cmp(r(1, 2)) != -1 || r(2, 4).Cmp(r(1, 2)) != 0 || r(-1, 2).Sign() != -1 {
+		t.Errorf("Cmp/Sign ordering broken")
+	}
+}
+
+func TestRational_Conversion(t *testing.T) {
+	third, _ := NewRational(1, 3)
+	if s := third.DecimalString(4); s != "0.3333" {
+		t.Errorf("DecimalString(4) = %s; expected 0.3333", s)
+	}
+	if f := third.Float64(); f != 1.0/3 {
+		t.Errorf("Float64() = %v; expected %v", f, 1.0/3)
+	}
+
+	r, err := RationalFromFloat(0.375)
+	if err != nil || r.String() != "3/8" {
+		t.Errorf("RationalFromFloat(0.375) = %s, %v; expected 3/8", r, err)
+	}
+	if _, err := RationalFromFloat(math.NaN()); !errors.Is(err, ErrInvalidRational) {
+		t.Errorf("RationalFromFloat(NaN) error = %v; expected %v", err, ErrInvalidRational)
+	}
+	if _, err := RationalFromFloat(1e300); !errors.Is(err, ErrOverflow) {
+		t.Errorf("RationalFromFloat(1e300) error = %v; expected %v", err, ErrOverflow)
+	}
+}
+
+func TestEval_Exact(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+		kind     Kind
+	}{
+		{"division", "7 / 2", "7/2", KindRational},
+		{"remainder sum", "1/3 + 1/6", "1/2", KindRational},
+		{"collapses to int", "1/3 * 3", "1", KindInt},
+		{"negative power", "2^-3", "1/8", KindRational},
+		{"rational power", "(2/3)^2", "4/9", KindRational},
+		{"modulo", "(7/2) % 1", "1/2", KindRational},
+		{"negation", "-(1/4)", "-1/4", KindRational},
+		{"abs", "abs(-1/4)", "1/4", KindRational},
+		{"float contaminates", "1/2 + 0.25", "0.75", KindFloat},
+		{"fractional exponent", "(1/4)^(1/2)", "0.5", KindFloat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEvaluator()
+			e.Exact = true
+			result, err := e.Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+			if result.String() != tt.expected || result.Kind() != tt.kind {
+				t.Errorf("Eval(%q) = %v (%v); expected %v (%v)",
+					tt.expr, result, result.Kind(), tt.expected, tt.kind)
+			}
+		})
+	}
+}
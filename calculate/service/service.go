@@ -0,0 +1,202 @@
+// Package service serves package calculate as a JSON API over HTTP:
+//
+//	POST /eval          {"expr": "2 * x", "vars": {"x": 3}} → {"result": 6, "kind": "int"}
+//	GET  /add?x=1&y=2   → {"result": 3, "kind": "int"}
+//	GET  /multiply?x=1.5&y=2
+//
+// Failures are reported as {"error": {"code": ..., "message": ...}} with a
+// 4xx status, or 504 when evaluation runs out of time; syntax and
+// evaluation errors also carry the column.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-lessons/calculate"
+)
+
+const (
+	DefaultMaxBodyBytes = 64 << 10
+	DefaultEvalTimeout  = time.Second
+)
+
+// Config limits the work a single request may cause. Zero fields take the
+// defaults above.
+type Config struct {
+	MaxBodyBytes int64
+	EvalTimeout  time.Duration
+}
+
+// Error codes of the "code" field in error responses.
+const (
+	CodeInvalidRequest = "invalid_request"
+	CodeTooLarge       = "too_large"
+	CodeSyntax         = "syntax_error"
+	CodeEval           = "eval_error"
+	CodeTimeout        = "timeout"
+)
+
+// EvalRequest is the body of POST /eval. Funcs holds definitions such as
+// "sq(x) = x^2" made available to Expr.
+type EvalRequest struct {
+	Expr     string                     `json:"expr"`
+	Vars     map[string]calculate.Value `json:"vars,omitempty"`
+	Funcs    []string                   `json:"funcs,omitempty"`
+	Exact    bool                       `json:"exact,omitempty"`
+	CaretXor bool                       `json:"caret_xor,omitempty"`
+}
+
+type Result struct {
+	Result calculate.Value `json:"result"`
+	Kind   string          `json:"kind"`
+}
+
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Column  int    `json:"column,omitempty"`
+}
+
+type server struct {
+	cfg Config
+}
+
+func NewHandler(cfg Config) http.Handler {
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if cfg.EvalTimeout <= 0 {
+		cfg.EvalTimeout = DefaultEvalTimeout
+	}
+	s := &server{cfg: cfg}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /eval", s.eval)
+	mux.HandleFunc("GET /add", s.binary(calculate.AddChecked[int], calculate.Add[float64]))
+	mux.HandleFunc("GET /multiply", s.binary(calculate.MulChecked[int], calculate.Multiply[float64]))
+	return mux
+}
+
+func (s *server) eval(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.cfg.MaxBodyBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	var req EvalRequest
+	if err := dec.Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, CodeTooLarge, err)
+			return
+		}
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.Expr) == "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, errors.New("expr is required"))
+		return
+	}
+
+	e := calculate.NewEvaluator()
+	e.Exact, e.CaretXor = req.Exact, req.CaretXor
+	for name, v := range req.Vars {
+		e.Vars[name] = v
+	}
+	for _, def := range req.Funcs {
+		n, err := e.Parse(def)
+		if err == nil {
+			if _, ok := n.(*calculate.FuncDef); ok {
+				_, err = e.EvalNode(n)
+			} else {
+				err = errors.New("not a function definition")
+			}
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("funcs: %q: %w", def, err))
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.EvalTimeout)
+	defer cancel()
+	v, err := e.EvalContext(ctx, req.Expr)
+	if err != nil {
+		writeEvalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Result{Result: v, Kind: v.Kind().String()})
+}
+
+// binary serves x and y from the query string to intOp when both are
+// integers and to floatOp otherwise.
+func (s *server) binary(intOp func(a, b int) (int, error), floatOp func(a, b float64) float64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		x, y := q.Get("x"), q.Get("y")
+		if x == "" || y == "" {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, errors.New("x and y are required"))
+			return
+		}
+
+		a, errA := calculate.ParseInt(x)
+		b, errB := calculate.ParseInt(y)
+		if errA == nil && errB == nil {
+			v, err := intOp(a, b)
+			if err != nil {
+				writeError(w, http.StatusUnprocessableEntity, CodeEval, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, Result{Result: calculate.IntValue(v), Kind: calculate.KindInt.String()})
+			return
+		}
+
+		fa, err := strconv.ParseFloat(x, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("x: invalid number %q", x))
+			return
+		}
+		fb, err := strconv.ParseFloat(y, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("y: invalid number %q", y))
+			return
+		}
+		v := calculate.FloatValue(floatOp(fa, fb))
+		writeJSON(w, http.StatusOK, Result{Result: v, Kind: calculate.KindFloat.String()})
+	}
+}
+
+func writeEvalError(w http.ResponseWriter, err error) {
+	var syntaxErr *calculate.SyntaxError
+	var evalErr *calculate.EvalError
+	switch {
+	case errors.As(err, &syntaxErr):
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{ErrorBody{
+			Code: CodeSyntax, Message: syntaxErr.Msg, Column: syntaxErr.Column}})
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, CodeTimeout, errors.New("evaluation timed out"))
+	case errors.As(err, &evalErr):
+		writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{ErrorBody{
+			Code: CodeEval, Message: evalErr.Err.Error(), Column: evalErr.Column}})
+	default:
+		writeError(w, http.StatusUnprocessableEntity, CodeEval, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, ErrorResponse{ErrorBody{Code: code, Message: err.Error()}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
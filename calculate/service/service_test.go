@@ -0,0 +1,151 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func do(t *testing.T, srv *httptest.Server, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var out map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("%s %s: decoding response: %v", method, path, err)
+	}
+	return resp.StatusCode, out
+}
+
+func TestEval(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(Config{}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		body     string
+		status   int
+		expected string // JSON of the whole response
+	}{
+		{"int", `{"expr": "2 + 3 * 4"}`, 200, `{"kind":"int","result":14}`},
+		{"float", `{"expr": "7 / 2"}`, 200, `{"kind":"float","result":3.5}`},
+		{"exact", `{"expr": "1/3 + 1/6", "exact": true}`, 200, `{"kind":"rational","result":"1/2"}`},
+		{"vars", `{"expr": "x * y", "vars": {"x": 6, "y": "1/2"}}`, 200, `{"kind":"int","result":3}`},
+		{"funcs", `{"expr": "sq(5)", "funcs": ["sq(n) = n^2"]}`, 200, `{"kind":"int","result":25}`},
+		{"caret xor", `{"expr": "6 ^ 3", "caret_xor": true}`, 200, `{"kind":"int","result":5}`},
+		{"syntax error", `{"expr": "1 +"}`, 400,
+			`{"error":{"code":"syntax_error","column":4,"message":"unexpected end of input"}}`},
+		{"eval error", `{"expr": "1 + 4 / 0"}`, 422,
+			`{"error":{"code":"eval_error","column":7,"message":"division by zero"}}`},
+		{"missing expr", `{}`, 400, `{"error":{"code":"invalid_request","message":"expr is required"}}`},
+		{"unknown field", `{"expr": "1", "mode": 2}`, 400,
+			`{"error":{"code":"invalid_request","message":"json: unknown field \"mode\""}}`},
+		{"bad funcs", `{"expr": "1", "funcs": ["x = 2"]}`, 400,
+			`{"error":{"code":"invalid_request","message":"funcs: \"x = 2\": not a function definition"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, out := do(t, srv, "POST", "/eval", tt.body)
+			got, _ := json.Marshal(out)
+			if status != tt.status || string(got) != tt.expected {
+				t.Errorf("POST /eval %s = %d %s; expected %d %s", tt.body, status, got, tt.status, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEval_Limits(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(Config{MaxBodyBytes: 1024, EvalTimeout: 20 * time.Millisecond}))
+	defer srv.Close()
+
+	big := `{"expr": "` + strings.Repeat("1+", 1000) + `1"}`
+	if status, out := do(t, srv, "POST", "/eval", big); status != 413 || code(out) != CodeTooLarge {
+		t.Errorf("oversized body = %d %v; expected 413 %s", status, out, CodeTooLarge)
+	}
+
+	// f30 makes 2^30 calls, far more than fit in the timeout.
+	funcs := []string{`"f0(x) = x"`}
+	for i := 1; i <= 30; i++ {
+		funcs = append(funcs, fmt.Sprintf(`"f%d(x) = f%d(x) + f%d(x)"`, i, i-1, i-1))
+	}
+	body := `{"expr": "f30(1)", "funcs": [` + strings.Join(funcs, ",") + `]}`
+	start := time.Now()
+	status, out := do(t, srv, "POST", "/eval", body)
+	if status != 504 || code(out) != CodeTimeout {
+		t.Errorf("slow expression = %d %v; expected 504 %s", status, out, CodeTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("slow expression took %v despite the timeout", elapsed)
+	}
+
+	// Huge powers are refused before any big arithmetic.
+	for _, body := range []string{
+		`{"expr": "x^30000000", "vars": {"x": "3/2"}}`,
+		`{"expr": "2^-30000000", "exact": true}`,
+	} {
+		start := time.Now()
+		if status, out := do(t, srv, "POST", "/eval", body); status < 400 {
+			t.Errorf("POST /eval %s = %d %v; expected an error", body, status, out)
+		}
+		if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+			t.Errorf("POST /eval %s took %v, longer than the timeout", body, elapsed)
+		}
+	}
+
+	resp, err := srv.Client().Get(srv.URL + "/eval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET /eval = %d; expected %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestAddMultiply(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(Config{}))
+	defer srv.Close()
+
+	tests := []struct {
+		path     string
+		status   int
+		expected string
+	}{
+		{"/add?x=2&y=3", 200, `{"kind":"int","result":5}`},
+		{"/add?x=0x10&y=-1", 200, `{"kind":"int","result":15}`},
+		{"/add?x=1.5&y=2", 200, `{"kind":"float","result":3.5}`},
+		{"/multiply?x=6&y=7", 200, `{"kind":"int","result":42}`},
+		{"/multiply?x=0.5&y=0.5", 200, `{"kind":"float","result":0.25}`},
+		{"/multiply?x=9223372036854775807&y=2", 422, `{"error":{"code":"eval_error","message":"integer overflow"}}`},
+		{"/add?x=1", 400, `{"error":{"code":"invalid_request","message":"x and y are required"}}`},
+		{"/add?x=one&y=2", 400, `{"error":{"code":"invalid_request","message":"x: invalid number \"one\""}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			status, out := do(t, srv, "GET", tt.path, "")
+			got, _ := json.Marshal(out)
+			if status != tt.status || string(got) != tt.expected {
+				t.Errorf("GET %s = %d %s; expected %d %s", tt.path, status, got, tt.status, tt.expected)
+			}
+		})
+	}
+}
+
+func code(out map[string]any) string {
+	body, _ := out["error"].(map[string]any)
+	c, _ := body["code"].(string)
+	return c
+}
@@ -0,0 +1,220 @@
+package calculate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// Entry is one successfully evaluated line of a Session. Function
+// definitions have no result and set Def instead.
+type Entry struct {
+	Expr   string `json:"expr"`
+	Result Value  `json:"result"`
+	Def    bool   `json:"def,omitempty"`
+}
+
+// assignment records a variable change so it can be undone; Old is nil when
+// the variable did not exist before.
+type assignment struct {
+	Name string `json:"name"`
+	Old  *Value `json:"old,omitempty"`
+	New  Value  `json:"new"`
+}
+
+// Session is an Evaluator together with the history of the lines it
+// evaluated. Variable assignments can be undone and redone, and the whole
+// session can be saved to and loaded from a JSON file.
+type Session struct {
+	Evaluator *Evaluator
+	History   []Entry
+
+	// Ans names a variable that every result is also assigned to, such
+	// as "ans"; empty disables it. It is undone together with its line.
+	Ans string
+
+	// undo and redo hold one step per line, listing every variable the
+	// line changed in order.
+	undo, redo [][]assignment
+}
+
+func NewSession() *Session {
+	return &Session{Evaluator: NewEvaluator()}
+}
+
+// Eval evaluates expr and records it in the history. Failed lines are not
+// recorded. A line that changes a variable, including Ans, clears the redo
+// stack.
+func (s *Session) Eval(expr string) (Entry, error) {
+	n, err := s.Evaluator.Parse(expr)
+	if err != nil {
+		return Entry{}, err
+	}
+	var step []assignment
+	record := func(name string) {
+		a := assignment{Name: name}
+		if old, ok := s.Evaluator.Vars[name]; ok {
+			a.Old = &old
+		}
+		step = append(step, a)
+	}
+	if a, ok := n.(*AssignStmt); ok {
+		record(a.Name)
+	}
+	v, err := s.Evaluator.EvalNode(n)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	_, def := n.(*FuncDef)
+	if s.Ans != "" && !def {
+		record(s.Ans)
+		s.Evaluator.Vars[s.Ans] = v
+	}
+	entry := Entry{Expr: expr, Result: v, Def: def}
+	s.History = append(s.History, entry)
+	if len(step) > 0 {
+		for i := range step {
+			step[i].New = v
+		}
+		s.undo = append(s.undo, step)
+		s.redo = nil
+	}
+	return entry, nil
+}
+
+// Undo reverts the variables changed by the most recent line that changed
+// any and returns their names.
+func (s *Session) Undo() ([]string, error) {
+	if len(s.undo) == 0 {
+		return nil, ErrNothingToUndo
+	}
+	step := s.undo[len(s.undo)-1]
+	s.undo = s.undo[:len(s.undo)-1]
+	for i := len(step) - 1; i >= 0; i-- {
+		if a := step[i]; a.Old == nil {
+			delete(s.Evaluator.Vars, a.Name)
+		} else {
+			s.Evaluator.Vars[a.Name] = *a.Old
+		}
+	}
+	s.redo = append(s.redo, step)
+	return names(step), nil
+}
+
+// Redo reapplies the most recently undone line and returns the names of
+// the variables it set.
+func (s *Session) Redo() ([]string, error) {
+	if len(s.redo) == 0 {
+		return nil, ErrNothingToRedo
+	}
+	step := s.redo[len(s.redo)-1]
+	s.redo = s.redo[:len(s.redo)-1]
+	for _, a := range step {
+		s.Evaluator.Vars[a.Name] = a.New
+	}
+	s.undo = append(s.undo, step)
+	return names(step), nil
+}
+
+func names(step []assignment) []string {
+	var names []string
+	for _, a := range step {
+		if !slices.Contains(names, a.Name) {
+			names = append(names, a.Name)
+		}
+	}
+	return names
+}
+
+// sessionJSON is the file format of a saved Session. Functions are stored
+// as their definitions, which String prints in the default syntax.
+type sessionJSON struct {
+	Exact    bool             `json:"exact,omitempty"`
+	DivMode  DivMode          `json:"div_mode,omitempty"`
+	CaretXor bool             `json:"caret_xor,omitempty"`
+	MaxDepth int              `json:"max_depth,omitempty"`
+	Ans      string           `json:"ans,omitempty"`
+	Vars     map[string]Value `json:"vars"`
+	Funcs    []string         `json:"funcs,omitempty"`
+	History  []Entry          `json:"history"`
+	Undo     [][]assignment   `json:"undo,omitempty"`
+	Redo     [][]assignment   `json:"redo,omitempty"`
+}
+
+func (s *Session) MarshalJSON() ([]byte, error) {
+	e := s.Evaluator
+	data := sessionJSON{
+		Exact:    e.Exact,
+		DivMode:  e.DivMode,
+		CaretXor: e.CaretXor,
+		MaxDepth: e.MaxDepth,
+		Ans:      s.Ans,
+		Vars:     e.Vars,
+		History:  s.History,
+		Undo:     s.undo,
+		Redo:     s.redo,
+	}
+	for name, f := range e.Funcs {
+		def := FuncDef{Name: name, Params: f.Params, Body: f.Body}
+		data.Funcs = append(data.Funcs, def.String())
+	}
+	sort.Strings(data.Funcs)
+	return json.Marshal(data)
+}
+
+func (s *Session) UnmarshalJSON(b []byte) error {
+	var data sessionJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	e := NewEvaluator()
+	e.Exact, e.DivMode, e.CaretXor = data.Exact, data.DivMode, data.CaretXor
+	e.MaxDepth = data.MaxDepth
+	for name, v := range data.Vars {
+		e.Vars[name] = v
+	}
+	for _, src := range data.Funcs {
+		n, err := Parse(src)
+		if err != nil {
+			return fmt.Errorf("calculate: function %q: %w", src, err)
+		}
+		def, ok := n.(*FuncDef)
+		if !ok {
+			return fmt.Errorf("calculate: %q is not a function definition", src)
+		}
+		e.Funcs[def.Name] = &Func{Params: def.Params, Body: def.Body}
+	}
+	*s = Session{Evaluator: e, History: data.History, Ans: data.Ans, undo: data.Undo, redo: data.Redo}
+	return nil
+}
+
+// Save writes the session to path as JSON.
+func (s *Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadSession reads a session written by Save.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := new(Session)
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("calculate: loading %s: %w", path, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,170 @@
+package calculate
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSession_UndoRedo(t *testing.T) {
+	s := NewSession()
+	for _, line := range []string{"x = 1", "x = x + 1", "y = 10", "x * y"} {
+		if _, err := s.Eval(line); err != nil {
+			t.Fatalf("Eval(%q) error: %v", line, err)
+		}
+	}
+
+	steps := []struct {
+		action   string
+		name     string
+		expected map[string]Value
+	}{
+		{"undo", "y", map[string]Value{"x": IntValue(2)}},
+		{"undo", "x", map[string]Value{"x": IntValue(1)}},
+		{"redo", "x", map[string]Value{"x": IntValue(2)}},
+		{"undo", "x", map[string]Value{"x": IntValue(1)}},
+		{"undo", "x", map[string]Value{}},
+		{"redo", "x", map[string]Value{"x": IntValue(1)}},
+	}
+	for i, step := range steps {
+		var names []string
+		var err error
+		if step.action == "undo" {
+			names, err = s.Undo()
+		} else {
+			names, err = s.Redo()
+		}
+		if err != nil || !slices.Equal(names, []string{step.name}) {
+			t.Fatalf("step %d: %s = %q, %v; expected [%s]", i, step.action, names, err, step.name)
+		}
+		if len(s.Evaluator.Vars) != len(step.expected) {
+			t.Errorf("step %d: vars = %v; expected %v", i, s.Evaluator.Vars, step.expected)
+		}
+		for k, v := range step.expected {
+			if s.Evaluator.Vars[k] != v {
+				t.Errorf("step %d: %s = %v; expected %v", i, k, s.Evaluator.Vars[k], v)
+			}
+		}
+	}
+
+	// A new assignment drops what could have been redone.
+	s.Eval("z = 3")
+	if _, err := s.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Redo after assignment error = %v; expected %v", err, ErrNothingToRedo)
+	}
+	if len(s.History) != 5 {
+		t.Errorf("history has %d entries; expected 5", len(s.History))
+	}
+}
+
+func TestSession_Ans(t *testing.T) {
+	s := NewSession()
+	s.Ans = "ans"
+	for _, line := range []string{"2 * 3", "sq(n) = n^2", "x = sq(ans)"} {
+		if _, err := s.Eval(line); err != nil {
+			t.Fatalf("Eval(%q) error: %v", line, err)
+		}
+	}
+	if s.Evaluator.Vars["ans"] != IntValue(36) {
+		t.Errorf("ans = %v; expected 36", s.Evaluator.Vars["ans"])
+	}
+
+	names, err := s.Undo()
+	if err != nil || !slices.Equal(names, []string{"x", "ans"}) {
+		t.Fatalf("Undo = %q, %v; expected [x ans]", names, err)
+	}
+	if _, ok := s.Evaluator.Vars["x"]; ok || s.Evaluator.Vars["ans"] != IntValue(6) {
+		t.Errorf("after undo vars = %v; expected only ans = 6", s.Evaluator.Vars)
+	}
+	s.Undo()
+	if _, ok := s.Evaluator.Vars["ans"]; ok {
+		t.Errorf("ans = %v after undoing its only line; expected undefined", s.Evaluator.Vars["ans"])
+	}
+	if _, err := s.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo past the start error = %v; expected %v", err, ErrNothingToUndo)
+	}
+}
+
+func TestSession_History(t *testing.T) {
+	s := NewSession()
+	s.Eval("1 / 0")
+	s.Eval("sq(n) = n^2")
+	entry, err := s.Eval("sq(3)")
+	if err != nil || entry.Result != IntValue(9) {
+		t.Fatalf("Eval = %+v, %v; expected result 9", entry, err)
+	}
+	if len(s.History) != 2 || !s.History[0].Def || s.History[1].Expr != "sq(3)" {
+		t.Errorf("History = %+v", s.History)
+	}
+	if _, err := NewSession().Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo on a new session error = %v; expected %v", err, ErrNothingToUndo)
+	}
+}
+
+func TestSession_SaveLoad(t *testing.T) {
+	s := NewSession()
+	s.Evaluator.Exact = true
+	s.Evaluator.CaretXor = true
+	s.Evaluator.MaxDepth = 20
+	s.Ans = "ans"
+	for _, line := range []string{"a = 1/3", "b = 2.0", "c = 7", "f(x) = x**2 ^ 1", "c = f(c)"} {
+		if _, err := s.Eval(line); err != nil {
+			t.Fatalf("Eval(%q) error: %v", line, err)
+		}
+	}
+	s.Undo()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := loaded.Evaluator
+	if !e.Exact || !e.CaretXor || e.MaxDepth != 20 || loaded.Ans != "ans" || len(loaded.History) != 5 {
+		t.Errorf("loaded settings or history lost: %+v", loaded)
+	}
+	for name, v := range map[string]Value{"a": RationalValue(Rational{num: 1, den: 3}), "b": FloatValue(2), "c": IntValue(7)} {
+		if e.Vars[name] != v || e.Vars[name].Kind() != v.Kind() {
+			t.Errorf("%s = %v (%v); expected %v (%v)", name, e.Vars[name], e.Vars[name].Kind(), v, v.Kind())
+		}
+	}
+	if names, err := loaded.Redo(); err != nil || !slices.Equal(names, []string{"c", "ans"}) ||
+		e.Vars["c"] != IntValue(48) || e.Vars["ans"] != IntValue(48) {
+		t.Errorf("Redo after load = %q, %v, c = %v; expected c = ans = 48", names, err, e.Vars["c"])
+	}
+	if entry, err := loaded.Eval("f(3)"); err != nil || entry.Result != IntValue(8) || e.Vars["ans"] != IntValue(8) {
+		t.Errorf("f(3) after load = %v, %v; expected 8", entry.Result, err)
+	}
+}
+
+func TestValue_JSON(t *testing.T) {
+	tests := []struct {
+		value Value
+		json  string
+	}{
+		{IntValue(-42), `-42`},
+		{FloatValue(2), `2.0`},
+		{FloatValue(1e21), `1e+21`},
+		{FloatValue(0.25), `0.25`},
+		{RationalValue(Rational{num: -1, den: 3}), `"-1/3"`},
+		{ComplexValue(3 - 4i), `"3-4i"`},
+		{ComplexValue(0.5i), `"0.5i"`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.value)
+		if err != nil || string(data) != tt.json {
+			t.Errorf("Marshal(%v) = %s, %v; expected %s", tt.value, data, err, tt.json)
+		}
+		var back Value
+		if err := json.Unmarshal(data, &back); err != nil || back != tt.value {
+			t.Errorf("Unmarshal(%s) = %v, %v; expected %v", data, back, err, tt.value)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"math"
+	"sync"
+
+	"golang-lessons/calculate"
+)
+
+// Accumulator keeps running statistics of a stream of values with
+// Welford's algorithm, so values need not be stored. The zero value is
+// ready to use and all methods are safe for concurrent use.
+type Accumulator[T calculate.Real] struct {
+	mu       sync.Mutex
+	n        int
+	mean, m2 float64
+	min, max T
+}
+
+// Summary is a snapshot of an Accumulator.
+type Summary[T calculate.Real] struct {
+	Count          int
+	Mean           float64
+	Variance       float64 // population variance
+	SampleVariance float64 // zero for a single value
+	StdDev         float64
+	Min, Max       T
+}
+
+func (a *Accumulator[T]) Add(xs ...T) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, x := range xs {
+		if a.n == 0 || x < a.min {
+			a.min = x
+		}
+		if a.n == 0 || x > a.max {
+			a.max = x
+		}
+		a.n++
+		d := float64(x) - a.mean
+		a.mean += d / float64(a.n)
+		a.m2 += d * (float64(x) - a.mean)
+	}
+}
+
+// Merge adds the values seen by o, as if they had been added to a. It
+// uses the pairwise update of Chan et al.
+func (a *Accumulator[T]) Merge(o *Accumulator[T]) {
+	o.mu.Lock()
+	n, mean, m2, lo, hi := o.n, o.mean, o.m2, o.min, o.max
+	o.mu.Unlock()
+	if n == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.n == 0 || lo < a.min {
+		a.min = lo
+	}
+	if a.n == 0 || hi > a.max {
+		a.max = hi
+	}
+	total := a.n + n
+	d := mean - a.mean
+	a.m2 += m2 + d*d*float64(a.n)*float64(n)/float64(total)
+	a.mean += d * float64(n) / float64(total)
+	a.n = total
+}
+
+func (a *Accumulator[T]) Count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.n
+}
+
+func (a *Accumulator[T]) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.n, a.mean, a.m2 = 0, 0, 0
+	a.min, a.max = 0, 0
+}
+
+// Summary returns the current statistics, or ErrEmpty before any value
+// was added.
+func (a *Accumulator[T]) Summary() (Summary[T], error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.n == 0 {
+		return Summary[T]{}, ErrEmpty
+	}
+	s := Summary[T]{
+		Count:    a.n,
+		Mean:     a.mean,
+		Variance: a.m2 / float64(a.n),
+		Min:      a.min,
+		Max:      a.max,
+	}
+	if a.n > 1 {
+		s.SampleVariance = a.m2 / float64(a.n-1)
+	}
+	s.StdDev = math.Sqrt(s.Variance)
+	return s, nil
+}
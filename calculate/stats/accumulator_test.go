@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestAccumulator_MatchesBatch(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	var acc Accumulator[float64]
+	acc.Add(data...)
+
+	s, err := acc.Summary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sampleVar, _ := SampleVariance(data)
+	if s.Count != 8 || s.Mean != 5 || s.Variance != 4 || s.StdDev != 2 || s.Min != 2 || s.Max != 9 {
+		t.Errorf("Summary = %+v", s)
+	}
+	if math.Abs(s.SampleVariance-sampleVar) > 1e-12 {
+		t.Errorf("SampleVariance = %v; expected %v", s.SampleVariance, sampleVar)
+	}
+}
+
+func TestAccumulator_Merge(t *testing.T) {
+	var a, b, all Accumulator[int]
+	a.Add(1, 2, 3)
+	b.Add(10, 20)
+	all.Add(1, 2, 3, 10, 20)
+	a.Merge(&b)
+
+	got, _ := a.Summary()
+	want, _ := all.Summary()
+	if got.Count != want.Count || got.Min != want.Min || got.Max != want.Max ||
+		math.Abs(got.Mean-want.Mean) > 1e-12 || math.Abs(got.Variance-want.Variance) > 1e-9 {
+		t.Errorf("merged = %+v; expected %+v", got, want)
+	}
+
+	var empty Accumulator[int]
+	empty.Merge(&b)
+	if s, _ := empty.Summary(); s.Min != 10 || s.Max != 20 {
+		t.Errorf("merge into empty = %+v", s)
+	}
+}
+
+func TestAccumulator_Concurrent(t *testing.T) {
+	var acc Accumulator[int]
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= 1000; i++ {
+				acc.Add(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s, _ := acc.Summary()
+	if s.Count != 8000 || math.Abs(s.Mean-500.5) > 1e-9 || s.Min != 1 || s.Max != 1000 {
+		t.Errorf("Summary = %+v", s)
+	}
+}
+
+func TestAccumulator_Empty(t *testing.T) {
+	var acc Accumulator[float32]
+	if _, err := acc.Summary(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Summary error = %v; expected %v", err, ErrEmpty)
+	}
+	acc.Add(1)
+	acc.Reset()
+	if acc.Count() != 0 {
+		t.Errorf("Count after Reset = %d; expected 0", acc.Count())
+	}
+}
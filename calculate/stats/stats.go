@@ -0,0 +1,172 @@
+// Package stats computes descriptive statistics over slices of any real
+// number type from package calculate. Results are float64 so that the mean
+// of integers is not truncated.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"golang-lessons/calculate"
+)
+
+var (
+	ErrEmpty      = calculate.ErrEmpty
+	ErrTooFew     = errors.New("need at least two values")
+	ErrPercentile = errors.New("percentile out of range")
+	ErrBuckets    = errors.New("bucket count must be positive")
+	ErrNotFinite  = errors.New("value is not finite")
+)
+
+func Mean[T calculate.Real](xs []T) (float64, error) {
+	if len(xs) == 0 {
+		return 0, ErrEmpty
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += float64(x)
+	}
+	return sum / float64(len(xs)), nil
+}
+
+// Median returns the middle value, or the mean of the two middle values
+// for an even count. xs is not modified.
+func Median[T calculate.Real](xs []T) (float64, error) {
+	return Percentile(xs, 50)
+}
+
+// Mode returns the most frequent values in ascending order; every value
+// is a mode when all occur equally often.
+func Mode[T calculate.Real](xs []T) ([]T, error) {
+	if len(xs) == 0 {
+		return nil, ErrEmpty
+	}
+	counts := make(map[T]int)
+	best := 0
+	for _, x := range xs {
+		counts[x]++
+		best = max(best, counts[x])
+	}
+	var modes []T
+	for x, n := range counts {
+		if n == best {
+			modes = append(modes, x)
+		}
+	}
+	slices.Sort(modes)
+	return modes, nil
+}
+
+// Variance returns the population variance. It uses a second pass over
+// the deviations from the mean, which is more accurate than the sum of
+// squares.
+func Variance[T calculate.Real](xs []T) (float64, error) {
+	ss, err := squaredDeviations(xs)
+	if err != nil {
+		return 0, err
+	}
+	return ss / float64(len(xs)), nil
+}
+
+// SampleVariance returns the unbiased sample variance, dividing by n-1.
+func SampleVariance[T calculate.Real](xs []T) (float64, error) {
+	if len(xs) == 1 {
+		return 0, ErrTooFew
+	}
+	ss, err := squaredDeviations(xs)
+	if err != nil {
+		return 0, err
+	}
+	return ss / float64(len(xs)-1), nil
+}
+
+func StdDev[T calculate.Real](xs []T) (float64, error) {
+	v, err := Variance(xs)
+	return math.Sqrt(v), err
+}
+
+func SampleStdDev[T calculate.Real](xs []T) (float64, error) {
+	v, err := SampleVariance(xs)
+	return math.Sqrt(v), err
+}
+
+func squaredDeviations[T calculate.Real](xs []T) (float64, error) {
+	mean, err := Mean(xs)
+	if err != nil {
+		return 0, err
+	}
+	var ss float64
+	for _, x := range xs {
+		d := float64(x) - mean
+		ss += d * d
+	}
+	return ss, nil
+}
+
+// Percentile returns the p-th percentile, 0 <= p <= 100, interpolating
+// linearly between the closest ranks. xs is not modified.
+func Percentile[T calculate.Real](xs []T, p float64) (float64, error) {
+	if len(xs) == 0 {
+		return 0, ErrEmpty
+	}
+	if !(p >= 0 && p <= 100) {
+		return 0, fmt.Errorf("%w: %v", ErrPercentile, p)
+	}
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	i := int(rank)
+	if i == len(sorted)-1 {
+		return float64(sorted[i]), nil
+	}
+	lo, hi := float64(sorted[i]), float64(sorted[i+1])
+	return lo + (hi-lo)*(rank-float64(i)), nil
+}
+
+// Bucket is one bar of a histogram: the values x with Lo <= x < Hi. The
+// last bucket also holds values equal to its Hi.
+type Bucket struct {
+	Lo, Hi float64
+	Count  int
+}
+
+// Histogram splits the range of xs into n buckets of equal width. When all
+// values are equal they land in a single bucket of zero width. NaN and
+// infinite values have no bucket and return ErrNotFinite.
+func Histogram[T calculate.Real](xs []T, n int) ([]Bucket, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrBuckets, n)
+	}
+	if len(xs) == 0 {
+		return nil, ErrEmpty
+	}
+	for _, x := range xs {
+		if f := float64(x); math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%w: %v", ErrNotFinite, f)
+		}
+	}
+	lo, hi := float64(slices.Min(xs)), float64(slices.Max(xs))
+	if lo == hi {
+		return []Bucket{{Lo: lo, Hi: hi, Count: len(xs)}}, nil
+	}
+
+	// Halve and divide before subtracting so that hi - lo cannot overflow.
+	width, span := hi/float64(n)-lo/float64(n), hi/2-lo/2
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i].Lo = lo + float64(i)*width
+		buckets[i].Hi = lo + float64(i+1)*width
+	}
+	buckets[n-1].Hi = hi
+	for _, x := range xs {
+		i := min(int((float64(x)/2-lo/2)/span*float64(n)), n-1)
+		buckets[i].Count++
+	}
+	return buckets, nil
+}
+
+func (b Bucket) String() string {
+	return fmt.Sprintf("[%g, %g): %d", b.Lo, b.Hi, b.Count)
+}
@@ -0,0 +1,116 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestStats_TableDriven(t *testing.T) {
+	data := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	tests := []struct {
+		name     string
+		fn       func([]int) (float64, error)
+		expected float64
+	}{
+		{"mean", Mean[int], 5},
+		{"median even", Median[int], 4.5},
+		{"variance", Variance[int], 4},
+		{"sample variance", SampleVariance[int], 32.0 / 7},
+		{"standard deviation", StdDev[int], 2},
+		{"sample standard deviation", SampleStdDev[int], math.Sqrt(32.0 / 7)},
+		{"first quartile", func(xs []int) (float64, error) { return Percentile(xs, 25) }, 4},
+		{"percentile interpolates", func(xs []int) (float64, error) { return Percentile(xs, 90) }, 7.6},
+		{"maximum", func(xs []int) (float64, error) { return Percentile(xs, 100) }, 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("got %v; expected %v", result, tt.expected)
+			}
+		})
+	}
+
+	if result, _ := Median([]float64{3, 1, 2}); result != 2 {
+		t.Errorf("Median odd = %v; expected 2", result)
+	}
+	if result, _ := Mean([]uint8{255, 255}); result != 255 {
+		t.Errorf("Mean([255 255]) = %v; expected 255 without wrap-around", result)
+	}
+	if !slices.Equal(data, []int{2, 4, 4, 4, 5, 5, 7, 9}) {
+		t.Errorf("input was modified: %v", data)
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		input    []int
+		expected []int
+	}{
+		{[]int{1, 2, 2, 3}, []int{2}},
+		{[]int{3, 1, 3, 1, 2}, []int{1, 3}},
+		{[]int{5}, []int{5}},
+	}
+
+	for _, tt := range tests {
+		result, err := Mode(tt.input)
+		if err != nil || !slices.Equal(result, tt.expected) {
+			t.Errorf("Mode(%v) = %v, %v; expected %v", tt.input, result, err, tt.expected)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	buckets, err := Histogram([]float64{0, 1, 2.5, 5, 7.5, 10}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Bucket{{0, 2.5, 2}, {2.5, 5, 1}, {5, 7.5, 1}, {7.5, 10, 2}}
+	if !slices.Equal(buckets, expected) {
+		t.Errorf("Histogram = %v; expected %v", buckets, expected)
+	}
+
+	buckets, _ = Histogram([]int{3, 3, 3}, 5)
+	if len(buckets) != 1 || buckets[0].Count != 3 {
+		t.Errorf("Histogram of equal values = %v; expected one bucket of 3", buckets)
+	}
+
+	buckets, err = Histogram([]float64{-math.MaxFloat64, 0, math.MaxFloat64}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buckets[0].Count != 1 || buckets[1].Count != 2 {
+		t.Errorf("Histogram of extreme values = %v; expected counts 1 and 2", buckets)
+	}
+}
+
+func TestStats_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func() error
+		err  error
+	}{
+		{"empty mean", func() error { _, err := Mean([]int{}); return err }, ErrEmpty},
+		{"empty mode", func() error { _, err := Mode([]int(nil)); return err }, ErrEmpty},
+		{"single sample variance", func() error { _, err := SampleVariance([]int{1}); return err }, ErrTooFew},
+		{"percentile above 100", func() error { _, err := Percentile([]int{1}, 101); return err }, ErrPercentile},
+		{"percentile NaN", func() error { _, err := Percentile([]int{1}, math.NaN()); return err }, ErrPercentile},
+		{"no buckets", func() error { _, err := Histogram([]int{1}, 0); return err }, ErrBuckets},
+		{"histogram NaN", func() error { _, err := Histogram([]float64{1, math.NaN(), 2}, 2); return err }, ErrNotFinite},
+		{"histogram infinity", func() error { _, err := Histogram([]float64{1, math.Inf(-1)}, 2); return err }, ErrNotFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); !errors.Is(err, tt.err) {
+				t.Errorf("error = %v; expected %v", err, tt.err)
+			}
+		})
+	}
+}
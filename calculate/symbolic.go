@@ -0,0 +1,592 @@
+package calculate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var ErrNotDifferentiable = errors.New("cannot differentiate")
+
+func number(v Value) *NumberLit {
+	return &NumberLit{Value: v}
+}
+
+func isNumber(n Node, f float64) bool {
+	lit, ok := n.(*NumberLit)
+	return ok && lit.Value.Complex128() == complex(f, 0)
+}
+
+// dependsOn reports whether the variable name occurs anywhere in n.
+func dependsOn(n Node, name string) bool {
+	switch n := n.(type) {
+	case *Ident:
+		return n.Name == name
+	case *UnaryExpr:
+		return dependsOn(n.X, name)
+	case *BinaryExpr:
+		return dependsOn(n.X, name) || dependsOn(n.Y, name)
+	case *CallExpr:
+		for _, arg := range n.Args {
+			if dependsOn(arg, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Derive returns the simplified derivative of n with respect to variable.
+// Every other name is treated as a constant.
+func Derive(n Node, variable string) (Node, error) {
+	d, err := derive(n, variable)
+	if err != nil {
+		return nil, err
+	}
+	return Simplify(d), nil
+}
+
+func derive(n Node, v string) (Node, error) {
+	switch n.(type) {
+	case *AssignStmt, *FuncDef:
+		return nil, &EvalError{Column: n.Pos(), Err: fmt.Errorf("%w a statement", ErrNotDifferentiable)}
+	}
+	if !dependsOn(n, v) {
+		return number(IntValue(0)), nil
+	}
+
+	switch n := n.(type) {
+	case *Ident:
+		return number(IntValue(1)), nil
+	case *UnaryExpr:
+		if n.Op == OpNot {
+			break
+		}
+		dx, err := derive(n.X, v)
+		if err != nil || n.Op == OpAdd {
+			return dx, err
+		}
+		return &UnaryExpr{Op: OpSub, X: dx}, nil
+	case *BinaryExpr:
+		dx, err := derive(n.X, v)
+		if err != nil {
+			return nil, err
+		}
+		dy, err := derive(n.Y, v)
+		if err != nil {
+			return nil, err
+		}
+		x, y := n.X, n.Y
+		switch n.Op {
+		case OpAdd, OpSub:
+			return &BinaryExpr{Op: n.Op, X: dx, Y: dy}, nil
+		case OpMul:
+			return add(mul(dx, y), mul(x, dy)), nil
+		case OpDiv:
+			return div(sub(mul(dx, y), mul(x, dy)), pow(y, number(IntValue(2)))), nil
+		case OpPow:
+			switch {
+			case !dependsOn(y, v):
+				// Power rule: (u^k)' = k·u^(k-1)·u'
+				return mul(mul(y, pow(x, sub(y, number(IntValue(1))))), dx), nil
+			case !dependsOn(x, v):
+				// (a^u)' = a^u·ln(a)·u'
+				return mul(mul(n, call("log", x)), dy), nil
+			}
+			// (u^w)' = u^w·(w'·ln(u) + w·u'/u)
+			return mul(n, add(mul(dy, call("log", x)), div(mul(y, dx), x))), nil
+		}
+	case *CallExpr:
+		return deriveCall(n, v)
+	}
+	return nil, &EvalError{Column: n.Pos(), Err: fmt.Errorf("%w %v", ErrNotDifferentiable, n)}
+}
+
+func deriveCall(n *CallExpr, v string) (Node, error) {
+	if n.Name == "pow" && len(n.Args) == 2 {
+		return derive(pow(n.Args[0], n.Args[1]), v)
+	}
+	if n.Name == "log" && len(n.Args) == 2 {
+		return derive(div(call("log", n.Args[0]), call("log", n.Args[1])), v)
+	}
+	if len(n.Args) != 1 {
+		return nil, &EvalError{Column: n.Column, Err: fmt.Errorf("%w %v", ErrNotDifferentiable, n)}
+	}
+
+	u := n.Args[0]
+	du, err := derive(u, v)
+	if err != nil {
+		return nil, err
+	}
+	var outer Node
+	switch n.Name {
+	case "sin":
+		outer = call("cos", u)
+	case "cos":
+		outer = &UnaryExpr{Op: OpSub, X: call("sin", u)}
+	case "tan":
+		outer = div(number(IntValue(1)), pow(call("cos", u), number(IntValue(2))))
+	case "exp":
+		outer = n
+	case "log":
+		outer = div(number(IntValue(1)), u)
+	case "sqrt":
+		outer = div(number(IntValue(1)), mul(number(IntValue(2)), n))
+	case "abs":
+		outer = div(u, n)
+	default:
+		return nil, &EvalError{Column: n.Column, Err: fmt.Errorf("%w %v", ErrNotDifferentiable, n)}
+	}
+	return mul(outer, du), nil
+}
+
+func add(x, y Node) Node { return &BinaryExpr{Op: OpAdd, X: x, Y: y} }
+func sub(x, y Node) Node { return &BinaryExpr{Op: OpSub, X: x, Y: y} }
+func mul(x, y Node) Node { return &BinaryExpr{Op: OpMul, X: x, Y: y} }
+func div(x, y Node) Node { return &BinaryExpr{Op: OpDiv, X: x, Y: y} }
+func pow(x, y Node) Node { return &BinaryExpr{Op: OpPow, X: x, Y: y} }
+
+func call(name string, args ...Node) Node {
+	return &CallExpr{Name: name, Args: args}
+}
+
+// Simplify folds constants, removes identities such as x*1, x+0 and x^1,
+// merges repeated factors into powers and combines like terms in sums.
+// It does not expand products of sums.
+//
+// Like most computer algebra, Simplify ignores where an expression is
+// undefined: x/x and 0/x become 1 and 0 although neither exists at x = 0.
+// It never changes a value where the expression is defined, so
+// (x^2)^(1/2) becomes abs(x) rather than x.
+func Simplify(n Node) Node {
+	switch n := n.(type) {
+	case *UnaryExpr:
+		x := Simplify(n.X)
+		switch n.Op {
+		case OpAdd:
+			return x
+		case OpNot:
+			if lit, ok := x.(*NumberLit); ok {
+				if v, err := bitNot(lit.Value); err == nil {
+					return number(v)
+				}
+			}
+			return &UnaryExpr{Column: n.Column, Op: OpNot, X: x}
+		}
+		return simplifySum(&UnaryExpr{Op: OpSub, X: x})
+	case *BinaryExpr:
+		x, y := Simplify(n.X), Simplify(n.Y)
+		if v, ok := fold(n.Op, x, y); ok {
+			return number(v)
+		}
+		switch n.Op {
+		case OpAdd, OpSub, OpMul:
+			return simplifySum(&BinaryExpr{Op: n.Op, X: x, Y: y})
+		case OpDiv:
+			switch {
+			case isNumber(y, 1):
+				return x
+			case !isNumber(y, 0):
+				return simplifySum(&BinaryExpr{Op: OpDiv, X: x, Y: y})
+			}
+		case OpPow:
+			switch {
+			case isNumber(y, 0), isNumber(x, 1):
+				return number(IntValue(1))
+			case isNumber(y, 1):
+				return x
+			}
+			if inner, ok := x.(*BinaryExpr); ok && inner.Op == OpPow {
+				// (u^a)^b = u^(a·b) for constant exponents when b is whole
+				// or a odd. An even a loses the sign of u, so |u| takes its
+				// place; other exponents are left alone.
+				if a, ok := inner.Y.(*NumberLit); ok {
+					if b, ok := y.(*NumberLit); ok {
+						if v, err := (&Evaluator{}).binary(OpMul, a.Value, b.Value); err == nil {
+							aWhole, aOdd := integral(a.Value)
+							switch bWhole, _ := integral(b.Value); {
+							case bWhole, aOdd:
+								return Simplify(pow(inner.X, number(v)))
+							case aWhole:
+								return Simplify(pow(call("abs", inner.X), number(v)))
+							}
+						}
+					}
+				}
+			}
+		}
+		return &BinaryExpr{Column: n.Column, Op: n.Op, X: x, Y: y}
+	case *CallExpr:
+		args := make([]Node, len(n.Args))
+		literal := true
+		for i, arg := range n.Args {
+			args[i] = Simplify(arg)
+			_, ok := args[i].(*NumberLit)
+			literal = literal && ok
+		}
+		if n.Name == "log" && len(args) == 1 {
+			if id, ok := args[0].(*Ident); ok && id.Name == "e" {
+				return number(IntValue(1))
+			}
+		}
+		c := &CallExpr{Column: n.Column, Name: n.Name, Args: args}
+		if literal {
+			if v, err := NewEvaluator().EvalNode(c); err == nil && v.kind == KindInt {
+				return number(v)
+			}
+		}
+		return c
+	case *AssignStmt:
+		return &AssignStmt{Column: n.Column, Name: n.Name, X: Simplify(n.X)}
+	case *FuncDef:
+		return &FuncDef{Column: n.Column, Name: n.Name, Params: n.Params, Body: Simplify(n.Body)}
+	}
+	return n
+}
+
+// integral reports whether v is a whole number and, if so, whether it is
+// odd.
+func integral(v Value) (whole, odd bool) {
+	switch v.kind {
+	case KindInt:
+		return true, v.i%2 != 0
+	case KindFloat:
+		if v.f == math.Trunc(v.f) && !math.IsInf(v.f, 0) {
+			return true, math.Mod(v.f, 2) != 0
+		}
+	}
+	return false, false
+}
+
+// fold evaluates op on two literals when the result stays exact: ints,
+// rationals, or anything involving a float or complex literal already.
+func fold(op Op, x, y Node) (Value, bool) {
+	a, ok1 := x.(*NumberLit)
+	b, ok2 := y.(*NumberLit)
+	if !ok1 || !ok2 {
+		return Value{}, false
+	}
+	v, err := (&Evaluator{Exact: true}).binary(op, a.Value, b.Value)
+	if err != nil {
+		return Value{}, false
+	}
+	inexact := func(k Kind) bool { return k == KindFloat || k == KindComplex }
+	return v, !inexact(v.kind) || inexact(a.Value.kind) || inexact(b.Value.kind)
+}
+
+type term struct {
+	coef   Value
+	factor Node // nil for the constant term
+}
+
+// simplifySum flattens a sum of products into coefficient·factor terms,
+// adds up the coefficients of equal factors and rebuilds the expression.
+func simplifySum(n Node) Node {
+	var terms []term
+	index := make(map[string]int)
+	collectTerms(n, IntValue(1), func(t term) {
+		key := ""
+		if t.factor != nil {
+			key = t.factor.String()
+		}
+		if i, ok := index[key]; ok {
+			terms[i].coef = addValues(terms[i].coef, t.coef)
+			return
+		}
+		index[key] = len(terms)
+		terms = append(terms, t)
+	})
+
+	// Keep the order of first appearance but move the constant to the end.
+	if i, ok := index[""]; ok {
+		c := terms[i]
+		terms = append(append(terms[:i:i], terms[i+1:]...), c)
+	}
+
+	var sum Node
+	for _, t := range terms {
+		if isZero(t.coef) {
+			continue
+		}
+		if sum == nil {
+			sum = makeTerm(t.coef, t.factor)
+		} else if t.coef.Float64() < 0 {
+			sum = sub(sum, makeTerm(negateValue(t.coef), t.factor))
+		} else {
+			sum = add(sum, makeTerm(t.coef, t.factor))
+		}
+	}
+	if sum == nil {
+		return number(IntValue(0))
+	}
+	return sum
+}
+
+func collectTerms(n Node, sign Value, emit func(term)) {
+	switch n := n.(type) {
+	case *BinaryExpr:
+		switch n.Op {
+		case OpAdd:
+			collectTerms(n.X, sign, emit)
+			collectTerms(n.Y, sign, emit)
+			return
+		case OpSub:
+			collectTerms(n.X, sign, emit)
+			collectTerms(n.Y, negateValue(sign), emit)
+			return
+		}
+	case *UnaryExpr:
+		if n.Op == OpSub {
+			collectTerms(n.X, negateValue(sign), emit)
+			return
+		}
+	}
+	coef, factor := splitProduct(n)
+	emit(term{coef: mulValues(coef, sign), factor: factor})
+}
+
+// splitProduct separates the numeric coefficient of a product from its
+// other factors, merging repeated bases into powers (2·x·3·x → 6, x^2) and
+// cancelling against divisors (x^3/x → x^2).
+func splitProduct(n Node) (Value, Node) {
+	coef := IntValue(1)
+	var bases []Node
+	exps := make(map[string]Node)
+
+	var walk func(n Node, inverse bool)
+	walk = func(n Node, inverse bool) {
+		switch f := n.(type) {
+		case *NumberLit:
+			if inverse {
+				if v, err := (&Evaluator{Exact: true}).binary(OpDiv, coef, f.Value); err == nil {
+					coef = v
+					return
+				}
+				break
+			}
+			coef = mulValues(coef, f.Value)
+			return
+		case *UnaryExpr:
+			if f.Op == OpNot {
+				break
+			}
+			if f.Op == OpSub {
+				coef = negateValue(coef)
+			}
+			walk(f.X, inverse)
+			return
+		case *BinaryExpr:
+			switch f.Op {
+			case OpMul:
+				walk(f.X, inverse)
+				walk(f.Y, inverse)
+				return
+			case OpDiv:
+				walk(f.X, inverse)
+				walk(f.Y, !inverse)
+				return
+			}
+		}
+		base, exp := n, Node(number(IntValue(1)))
+		if p, ok := n.(*BinaryExpr); ok && p.Op == OpPow {
+			base, exp = p.X, p.Y
+		}
+		if inverse {
+			exp = Simplify(&UnaryExpr{Op: OpSub, X: exp})
+		}
+		key := base.String()
+		if prev, ok := exps[key]; ok {
+			exps[key] = Simplify(add(prev, exp))
+			return
+		}
+		exps[key] = exp
+		bases = append(bases, base)
+	}
+	walk(n, false)
+
+	// Factors with a negative constant exponent move below the line.
+	var num, den Node
+	for _, base := range bases {
+		exp := exps[base.String()]
+		if isNumber(exp, 0) {
+			continue
+		}
+		target := &num
+		if lit, ok := exp.(*NumberLit); ok && lit.Value.Float64() < 0 {
+			target = &den
+			exp = number(negateValue(lit.Value))
+		}
+		f := base
+		if !isNumber(exp, 1) {
+			f = pow(base, exp)
+		}
+		if *target == nil {
+			*target = f
+		} else {
+			*target = mul(*target, f)
+		}
+	}
+	if den == nil {
+		return coef, num
+	}
+	if num == nil {
+		num = number(IntValue(1))
+	}
+	return coef, div(num, den)
+}
+
+func makeTerm(coef Value, factor Node) Node {
+	switch {
+	case factor == nil:
+		return number(coef)
+	case isZero(addValues(coef, IntValue(-1))):
+		return factor
+	}
+	// Fold the coefficient into a bare reciprocal: -1/x^2, 1/(2·sqrt(x)).
+	if d, ok := factor.(*BinaryExpr); ok && d.Op == OpDiv && isNumber(d.X, 1) {
+		switch coef.kind {
+		case KindInt:
+			return div(number(coef), d.Y)
+		case KindRational:
+			return div(number(IntValue(coef.r.num)), withCoefficient(number(IntValue(coef.r.den)), d.Y))
+		}
+	}
+	if isZero(addValues(coef, IntValue(1))) {
+		return &UnaryExpr{Op: OpSub, X: factor}
+	}
+	return withCoefficient(number(coef), factor)
+}
+
+// withCoefficient multiplies c onto the leftmost factor of a product so
+// the chain stays left-associative and prints without parentheses.
+func withCoefficient(c, factor Node) Node {
+	if f, ok := factor.(*BinaryExpr); ok && (f.Op == OpMul || f.Op == OpDiv) {
+		return &BinaryExpr{Op: f.Op, X: withCoefficient(c, f.X), Y: f.Y}
+	}
+	return mul(c, factor)
+}
+
+func addValues(a, b Value) Value {
+	v, err := (&Evaluator{Exact: true}).binary(OpAdd, a, b)
+	if err != nil {
+		return FloatValue(a.Float64() + b.Float64())
+	}
+	return v
+}
+
+func mulValues(a, b Value) Value {
+	v, err := (&Evaluator{Exact: true}).binary(OpMul, a, b)
+	if err != nil {
+		return FloatValue(a.Float64() * b.Float64())
+	}
+	return v
+}
+
+func negateValue(v Value) Value {
+	return mulValues(v, IntValue(-1))
+}
+
+func isZero(v Value) bool {
+	return v.Complex128() == 0
+}
+
+// LaTeX renders n as LaTeX math, using \frac for division and \cdot or
+// juxtaposition for multiplication.
+func LaTeX(n Node) string {
+	switch n := n.(type) {
+	case *NumberLit:
+		if r, ok := n.Value.Rational(); ok {
+			if r.num < 0 {
+				return fmt.Sprintf("-\\frac{%d}{%d}", -r.num, r.den)
+			}
+			return fmt.Sprintf("\\frac{%d}{%d}", r.num, r.den)
+		}
+		return n.String()
+	case *Ident:
+		switch {
+		case n.Name == "pi":
+			return "\\pi"
+		case len([]rune(n.Name)) == 1:
+			return n.Name
+		}
+		return "\\mathrm{" + n.Name + "}"
+	case *UnaryExpr:
+		op := n.Op.String()
+		if n.Op == OpNot {
+			op = "\\lnot "
+		}
+		return op + latexWrap(n.X, precedence(n.X) < precUnary)
+	case *BinaryExpr:
+		p := n.Op.precedence()
+		left := precedence(n.X) < p || precedence(n.X) == p && n.Op.rightAssoc()
+		right := precedence(n.Y) < p || precedence(n.Y) == p && !n.Op.rightAssoc()
+		switch n.Op {
+		case OpDiv:
+			return "\\frac{" + LaTeX(n.X) + "}{" + LaTeX(n.Y) + "}"
+		case OpPow:
+			return latexWrap(n.X, precedence(n.X) < precPrimary) + "^{" + LaTeX(n.Y) + "}"
+		case OpMul:
+			sep := " \\cdot "
+			if _, ok := n.X.(*NumberLit); ok && !left {
+				if _, ok := n.Y.(*NumberLit); !ok && !right {
+					sep = " "
+				}
+			}
+			return latexWrap(n.X, left) + sep + latexWrap(n.Y, right)
+		case OpMod:
+			return latexWrap(n.X, left) + " \\bmod " + latexWrap(n.Y, right)
+		case OpXor:
+			// String spells xor as a call, so precedence treats it as
+			// primary; parenthesize it here instead.
+			return "\\left(" + LaTeX(n.X) + " \\oplus " + LaTeX(n.Y) + "\\right)"
+		case OpAnd, OpOr, OpShl, OpShr:
+			return latexWrap(n.X, left) + " " + latexBitwise[n.Op] + " " + latexWrap(n.Y, right)
+		}
+		return latexWrap(n.X, left) + " " + n.Op.String() + " " + latexWrap(n.Y, right)
+	case *CallExpr:
+		args := make([]string, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = LaTeX(arg)
+		}
+		joined := strings.Join(args, ", ")
+		switch n.Name {
+		case "sqrt":
+			return "\\sqrt{" + joined + "}"
+		case "abs":
+			return "\\left|" + joined + "\\right|"
+		case "floor":
+			return "\\lfloor " + joined + " \\rfloor"
+		case "ceil":
+			return "\\lceil " + joined + " \\rceil"
+		case "exp":
+			return "e^{" + joined + "}"
+		case "sin", "cos", "tan", "log", "min", "max":
+			return "\\" + n.Name + "\\left(" + joined + "\\right)"
+		}
+		return "\\operatorname{" + n.Name + "}\\left(" + joined + "\\right)"
+	case *AssignStmt:
+		return LaTeX(&Ident{Name: n.Name}) + " = " + LaTeX(n.X)
+	case *FuncDef:
+		params := make([]Node, len(n.Params))
+		for i, p := range n.Params {
+			params[i] = &Ident{Name: p}
+		}
+		return LaTeX(&CallExpr{Name: n.Name, Args: params}) + " = " + LaTeX(n.Body)
+	}
+	return n.String()
+}
+
+var latexBitwise = map[Op]string{
+	OpAnd: "\\mathbin{\\&}",
+	OpOr:  "\\mathbin{|}",
+	OpShl: "\\ll",
+	OpShr: "\\gg",
+}
+
+func latexWrap(n Node, parens bool) string {
+	if parens {
+		return "\\left(" + LaTeX(n) + "\\right)"
+	}
+	return LaTeX(n)
+}
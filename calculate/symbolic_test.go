@@ -0,0 +1,142 @@
+package calculate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDerive_TableDriven(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+	}{
+		{"constant", "42", "0"},
+		{"other variable", "y^2", "0"},
+		{"power rule", "x^3", "3 * x^2"},
+		{"polynomial", "3x^2 - 2x + 1", "6 * x - 2"},
+		{"product rule", "x * sin(x)", "sin(x) + x * cos(x)"},
+		{"quotient rule", "x / (x + 1)", "1 / (x + 1)^2"},
+		{"reciprocal", "1 / x", "-1 / x^2"},
+		{"exponential base", "2^x", "2^x * log(2)"},
+		{"variable exponent", "x^x", "x^x * (log(x) + 1)"},
+		{"chain rule", "sqrt(x^2 + 1)", "x / sqrt(x^2 + 1)"},
+		{"exp", "exp(2x)", "2 * exp(2 * x)"},
+		{"log", "log(x)", "1 / x"},
+		{"cos", "cos(x)", "-sin(x)"},
+		{"symbolic coefficient", "a*x^2 + b*x", "2 * a * x + b"},
+		{"half", "x / 2", "1/2"},
+		{"sqrt", "sqrt(x)", "1 / (2 * sqrt(x))"},
+		{"cube root", "x^(1/3)", "1 / (3 * x^(2/3))"},
+		{"negative rational reciprocal", "-3 * x^(1/3)", "-1 / x^(2/3)"},
+		{"natural exponential", "e^x", "e^x"},
+		{"natural exponential chain", "e^(2x)", "2 * e^(2 * x)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := Derive(n, "x")
+			if err != nil {
+				t.Fatalf("Derive(%q) error: %v", tt.expr, err)
+			}
+			if result := d.String(); result != tt.expected {
+				t.Errorf("Derive(%q) = %q; expected %q", tt.expr, result, tt.expected)
+			}
+		})
+	}
+
+	for _, expr := range []string{"x % 2", "floor(x)", "y = x"} {
+		n, _ := Parse(expr)
+		if _, err := Derive(n, "x"); !errors.Is(err, ErrNotDifferentiable) {
+			t.Errorf("Derive(%q) error = %v; expected %v", expr, err, ErrNotDifferentiable)
+		}
+	}
+}
+
+func TestSimplify_TableDriven(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+	}{
+		{"add zero", "x + 0", "x"},
+		{"multiply by one", "1 * x * 1", "x"},
+		{"multiply by zero", "0 * y + y / 1", "y"},
+		{"power one", "x^1 + y^0", "x + 1"},
+		{"double negation", "--x", "x"},
+		{"fold constants", "2 * 3 + 4", "10"},
+		{"fold fractions", "1/2 + 1/3", "5/6"},
+		{"like terms", "x + x + 2x - 3", "4 * x - 3"},
+		{"cancel", "x - x", "0"},
+		{"merge factors", "2*x*3*x", "6 * x^2"},
+		{"cancel division", "x*x*x / x", "x^2"},
+		{"nested power", "(x^2)^3", "x^6"},
+		{"constant last", "1 + y + 2 + y", "2 * y + 3"},
+		{"call folding", "abs(-3) + x", "x + 3"},
+		{"leaves inexact division", "x + 1/3", "x + 1/3"},
+		{"bitwise not is not negation", "~x + x", "~x + x"},
+		{"fold bitwise", "x & (6 | 1) + ~0", "x & 6"},
+		{"zero over zero", "0 / 0", "0 / 0"},
+		{"zero over name", "0 / y + x", "x"},
+		{"cancel quotient", "x / x", "1"},
+		{"cancel squares", "x^2 / x^2 + y", "y + 1"},
+		{"cancel reciprocal", "x * (1 / x)", "1"},
+		{"even root of even power", "(x^2)^0.5", "abs(x)"},
+		{"rational root of even power", "(x^2)^(1/2)", "abs(x)"},
+		{"negative even power", "(x^-2)^(1/2)", "abs(x)^(-1)"},
+		{"odd power", "(x^3)^(1/3)", "x"},
+		{"whole outer power", "(x^0.5)^4", "x^2"},
+		{"fractional powers kept", "(x^(1/3))^(3/2)", "(x^(1/3))^(3/2)"},
+		{"zero over literal", "0 / 5 + x", "x"},
+		{"log of e", "log(e) * y", "y"},
+		{"call without args", "f() + 0", "f()"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := Simplify(n).String(); result != tt.expected {
+				t.Errorf("Simplify(%q) = %q; expected %q", tt.expr, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLaTeX(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+	}{
+		{"polynomial", "3x^2 - 2x + 1", `3 x^{2} - 2 x + 1`},
+		{"fraction", "(x + 1) / 2", `\frac{x + 1}{2}`},
+		{"grouping", "(a + b) * c", `\left(a + b\right) \cdot c`},
+		{"power base", "(x + 1)^2", `\left(x + 1\right)^{2}`},
+		{"functions", "sqrt(x) + sin(pi * x)", `\sqrt{x} + \sin\left(\pi \cdot x\right)`},
+		{"exp and abs", "exp(abs(t))", `e^{\left|t\right|}`},
+		{"long name", "rate * 2", `\mathrm{rate} \cdot 2`},
+		{"definition", "f(x) = x % 3", `\operatorname{f}\left(x\right) = x \bmod 3`},
+		{"bitwise", "~a & b << 2", `\lnot a \mathbin{\&} b \ll 2`},
+		{"xor", "xor(a, b) * c", `\operatorname{xor}\left(a, b\right) \cdot c`},
+		{"folded reciprocal", "1 / (3 * x^(2/3))", `\frac{1}{3 x^{\frac{2}{3}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := LaTeX(n); result != tt.expected {
+				t.Errorf("LaTeX(%q) = %q; expected %q", tt.expr, result, tt.expected)
+			}
+		})
+	}
+}
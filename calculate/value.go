@@ -0,0 +1,192 @@
+package calculate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Kind int
+
+const (
+	KindInt Kind = iota
+	KindFloat
+	KindRational
+	KindComplex
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float"
+	case KindRational:
+		return "rational"
+	case KindComplex:
+		return "complex"
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Value is the result of evaluating an expression. Integer operands stay
+// integers for as long as the result is exact.
+type Value struct {
+	kind Kind
+	i    int
+	r    Rational
+	f    float64
+	c    complex128
+}
+
+func IntValue(i int) Value {
+	return Value{kind: KindInt, i: i}
+}
+
+// RationalValue returns r as a Value; whole numbers become ints.
+func RationalValue(r Rational) Value {
+	if r.IsInt() {
+		return IntValue(r.num)
+	}
+	return Value{kind: KindRational, r: r}
+}
+
+func FloatValue(f float64) Value {
+	return Value{kind: KindFloat, f: f}
+}
+
+// ComplexValue returns c as a Value; numbers without an imaginary part
+// become floats.
+func ComplexValue(c complex128) Value {
+	if imag(c) == 0 {
+		return FloatValue(real(c))
+	}
+	return Value{kind: KindComplex, c: c}
+}
+
+func (v Value) Kind() Kind {
+	return v.kind
+}
+
+// Int returns the integer value and whether v holds an integer.
+func (v Value) Int() (int, bool) {
+	return v.i, v.kind == KindInt
+}
+
+// Rational returns the rational value and whether v holds one. Ints are
+// not reported as rationals.
+func (v Value) Rational() (Rational, bool) {
+	return v.r, v.kind == KindRational
+}
+
+// rational converts an int or rational value to a Rational.
+func (v Value) rational() Rational {
+	if v.kind == KindInt {
+		return Rational{num: v.i, den: 1}
+	}
+	return v.r
+}
+
+// Complex returns the complex value and whether v holds one.
+func (v Value) Complex() (complex128, bool) {
+	return v.c, v.kind == KindComplex
+}
+
+// Float64 returns v as a float64. A complex value gives only its real
+// part: the imaginary part is dropped silently, so code that cannot handle
+// complex numbers must check Kind first and reject KindComplex, as the
+// rounding functions and cmd/solve do.
+func (v Value) Float64() float64 {
+	switch v.kind {
+	case KindInt:
+		return float64(v.i)
+	case KindRational:
+		return v.r.Float64()
+	case KindComplex:
+		return real(v.c)
+	}
+	return v.f
+}
+
+func (v Value) Complex128() complex128 {
+	if v.kind == KindComplex {
+		return v.c
+	}
+	return complex(v.Float64(), 0)
+}
+
+func (v Value) String() string {
+	switch v.kind {
+	case KindInt:
+		return strconv.Itoa(v.i)
+	case KindRational:
+		return v.r.String()
+	case KindComplex:
+		return FormatComplex(v.c)
+	}
+	return strconv.FormatFloat(v.f, 'g', -1, 64)
+}
+
+// Polar prints complex values with FormatPolar and other values as String
+// does.
+func (v Value) Polar() string {
+	if v.kind == KindComplex {
+		return FormatPolar(v.c)
+	}
+	return v.String()
+}
+
+// MarshalJSON encodes ints and finite floats as JSON numbers, floats always
+// with a fraction or exponent so they decode as floats again, and
+// rationals, complex numbers and non-finite floats as strings such as
+// "1/3", "3-4i" and "+Inf".
+func (v Value) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.kind == KindRational, v.kind == KindComplex:
+		return []byte(strconv.Quote(v.String())), nil
+	case v.kind == KindFloat && (math.IsInf(v.f, 0) || math.IsNaN(v.f)):
+		return []byte(strconv.Quote(v.String())), nil
+	case v.kind == KindFloat:
+		s := v.String()
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return []byte(s), nil
+	}
+	return []byte(v.String()), nil
+}
+
+func (v *Value) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		if strings.Contains(unquoted, "/") {
+			r, err := ParseRational(unquoted)
+			if err != nil {
+				return err
+			}
+			*v = RationalValue(r)
+			return nil
+		}
+		if strings.HasSuffix(unquoted, "i") {
+			c, err := strconv.ParseComplex(unquoted, 128)
+			if err != nil {
+				return fmt.Errorf("calculate: invalid value %s", s)
+			}
+			*v = ComplexValue(c)
+			return nil
+		}
+		s = unquoted
+	} else if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.Atoi(s); err == nil {
+			*v = IntValue(i)
+			return nil
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("calculate: invalid value %s", s)
+	}
+	*v = FloatValue(f)
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// lineReader returns one line of input per call, or io.EOF at the end.
+type lineReader interface {
+	readLine(prompt string) (string, error)
+}
+
+// scanReader reads lines as they come, for pipes and terminals without
+// line editing.
+type scanReader struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+func (s scanReader) readLine(prompt string) (string, error) {
+	fmt.Fprint(s.out, prompt)
+	if !s.scanner.Scan() {
+		if err := s.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return s.scanner.Text(), nil
+}
+
+// lineEditor reads lines from a terminal in raw mode. It supports moving
+// the cursor with the arrow keys, Home and End, deleting with Backspace
+// and Delete, recalling earlier inputs with Up and Down, and the Emacs
+// keys Ctrl-A, E, B, F, P, N, K and U. Ctrl-C discards the line and
+// Ctrl-D on an empty line ends input.
+type lineEditor struct {
+	in      *bufio.Reader
+	out     io.Writer
+	history *[]string
+	// raw switches the terminal to raw mode for the duration of readLine,
+	// so that Ctrl-C still interrupts evaluation. Nil leaves it alone.
+	raw func() (restore func(), err error)
+}
+
+func ctrl(c rune) rune {
+	return c & 0x1f
+}
+
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	if e.raw != nil {
+		restore, err := e.raw()
+		if err != nil {
+			return "", err
+		}
+		defer restore()
+	}
+
+	var buf, draft []rune
+	pos := 0
+	recall := len(*e.history)
+	// show replaces the line with an earlier input, keeping what was being
+	// typed as a draft to come back to.
+	show := func(i int) {
+		history := *e.history
+		if i < 0 || i > len(history) {
+			return
+		}
+		if recall == len(history) {
+			draft = buf
+		}
+		recall = i
+		if i == len(history) {
+			buf = draft
+		} else {
+			buf = []rune(history[i])
+		}
+		pos = len(buf)
+	}
+
+	fmt.Fprint(e.out, prompt)
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\n")
+			return string(buf), nil
+		case ctrl('D'):
+			if len(buf) == 0 {
+				return "", io.EOF
+			}
+			if pos < len(buf) {
+				buf = append(buf[:pos:pos], buf[pos+1:]...)
+			}
+		case ctrl('C'):
+			fmt.Fprint(e.out, "^C\n")
+			buf, pos, recall = nil, 0, len(*e.history)
+		case 0x7f, ctrl('H'):
+			if pos > 0 {
+				buf = append(buf[:pos-1:pos-1], buf[pos:]...)
+				pos--
+			}
+		case ctrl('A'):
+			pos = 0
+		case ctrl('E'):
+			pos = len(buf)
+		case ctrl('B'):
+			pos = max(pos-1, 0)
+		case ctrl('F'):
+			pos = min(pos+1, len(buf))
+		case ctrl('P'):
+			show(recall - 1)
+		case ctrl('N'):
+			show(recall + 1)
+		case ctrl('K'):
+			buf = buf[:pos:pos]
+		case ctrl('U'):
+			buf, pos = buf[pos:], 0
+		case 0x1b:
+			switch e.escape() {
+			case "A":
+				show(recall - 1)
+			case "B":
+				show(recall + 1)
+			case "C":
+				pos = min(pos+1, len(buf))
+			case "D":
+				pos = max(pos-1, 0)
+			case "H", "1~", "7~":
+				pos = 0
+			case "F", "4~", "8~":
+				pos = len(buf)
+			case "3~":
+				if pos < len(buf) {
+					buf = append(buf[:pos:pos], buf[pos+1:]...)
+				}
+			}
+		default:
+			if !unicode.IsPrint(r) {
+				continue
+			}
+			buf = append(buf[:pos:pos], append([]rune{r}, buf[pos:]...)...)
+			pos++
+		}
+
+		// Redraw the whole line and put the cursor back at pos.
+		fmt.Fprint(e.out, "\r", prompt, string(buf), "\x1b[K")
+		if n := len(buf) - pos; n > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", n)
+		}
+	}
+}
+
+// escape reads the rest of an ESC [ or ESC O sequence and returns it
+// without the prefix: "A" for Up, "3~" for Delete. Other sequences yield "".
+func (e *lineEditor) escape() string {
+	r, _, err := e.in.ReadRune()
+	if err != nil || r != '[' && r != 'O' {
+		return ""
+	}
+	var seq strings.Builder
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return ""
+		}
+		seq.WriteRune(r)
+		if r < '0' || r > '9' && r != ';' {
+			return seq.String()
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineEditor(t *testing.T) {
+	const (
+		up, down, left, right = "\x1b[A", "\x1b[B", "\x1b[D", "\x1b[C"
+		home, end, del        = "\x1b[H", "\x1b[F", "\x1b[3~"
+		backspace             = "\x7f"
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		history  []string
+		expected string
+	}{
+		{"plain", "1 + 2\r", nil, "1 + 2"},
+		{"newline ends line", "3\n", nil, "3"},
+		{"backspace", "12" + backspace + "3\r", nil, "13"},
+		{"insert in the middle", "13" + left + "2\r", nil, "123"},
+		{"home and end", "2" + home + "1" + end + "3\r", nil, "123"},
+		{"delete", "1x2" + left + left + del + "\r", nil, "12"},
+		{"right stops at end", "1" + right + right + "2\r", nil, "12"},
+		{"emacs keys", "bc\x01a\x05d\r", nil, "abcd"},
+		{"kill to end", "12345\x01\x06\x06\x0b\r", nil, "12"},
+		{"kill to start", "12345\x02\x02\x15\r", nil, "45"},
+		{"up recalls last", up + "\r", []string{"1 + 1", "2 + 2"}, "2 + 2"},
+		{"up twice", up + up + "\r", []string{"1 + 1", "2 + 2"}, "1 + 1"},
+		{"up stops at first", up + up + up + "\r", []string{"1 + 1", "2 + 2"}, "1 + 1"},
+		{"down returns to draft", "x" + up + down + "\r", []string{"1 + 1"}, "x"},
+		{"edit recalled", up + backspace + "3\r", []string{"1 + 2"}, "1 + 3"},
+		{"ctrl-c discards", "1 +\x032\r", nil, "2"},
+		{"utf-8", "√" + left + "x\r", nil, "x√"},
+		{"unknown escape ignored", "1\x1b[5~2\r", nil, "12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := tt.history
+			e := &lineEditor{in: bufio.NewReader(strings.NewReader(tt.input)), out: io.Discard, history: &history}
+			line, err := e.readLine("> ")
+			if err != nil {
+				t.Fatalf("readLine error: %v", err)
+			}
+			if line != tt.expected {
+				t.Errorf("readLine = %q; expected %q", line, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLineEditor_EOF(t *testing.T) {
+	var history []string
+	for _, input := range []string{"", "\x04", "12\x7f\x7f\x04"} {
+		e := &lineEditor{in: bufio.NewReader(strings.NewReader(input)), out: io.Discard, history: &history}
+		if _, err := e.readLine("> "); err != io.EOF {
+			t.Errorf("readLine(%q) error = %v; expected io.EOF", input, err)
+		}
+	}
+}
+
+func TestLoop_LineEditor(t *testing.T) {
+	var out, errOut bytes.Buffer
+	r := newREPL(&out, &errOut)
+	r.loop(&lineEditor{
+		in:      bufio.NewReader(strings.NewReader("2 * 3\r\x1b[A\x7f4\r\x04")),
+		out:     &out,
+		history: &r.history,
+	})
+	if !strings.Contains(out.String(), "\n6\n") || !strings.Contains(out.String(), "\n8\n") {
+		t.Errorf("output = %q; expected 6 and then 8 from the edited recall", out.String())
+	}
+	if errOut.Len() > 0 {
+		t.Errorf("errors = %q", errOut.String())
+	}
+}
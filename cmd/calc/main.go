@@ -0,0 +1,316 @@
+// Command calc evaluates arithmetic expressions with package calculate.
+//
+// Run without arguments on a terminal it starts an interactive session
+// with line editing: the arrow keys move the cursor and recall earlier
+// inputs.
+// Given a file argument, or with stdin redirected, it evaluates one
+// expression per line and prints one result per line.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang-lessons/calculate"
+)
+
+const helpText = `Enter an expression such as 2 + 3 * 4 or assign a variable with x = 3.
+The previous result is available as ans. Define functions with f(x) = x^2 + 1.
+Constants: pi, e and the imaginary unit i, as in 3 + 4i.
+Built-in functions:
+  sqrt sin cos tan log exp pow abs floor ceil round min max div mod
+  popcount xor re im arg conj
+log(x) is the natural logarithm; log(x, b) takes the base second.
+
+Editing: Left/Right move the cursor, Up/Down recall earlier inputs,
+Home/End (Ctrl-A/Ctrl-E) jump to the ends, Ctrl-U and Ctrl-K delete to
+the start or end of the line, Ctrl-C discards it.
+
+Commands:
+  :help      show this help
+  :vars      list variables
+  :funcs     list user-defined functions
+  :history   list previous inputs
+  :undo      revert the variables, ans included, set by the last input
+  :redo      reapply an undone input
+  :save FILE save the session to FILE
+  :load FILE resume a session saved with :save
+  !!         repeat the last input
+  !n         repeat input number n from :history
+  :quit      exit (also :q or Ctrl-D)
+`
+
+type repl struct {
+	session *calculate.Session
+	history []string
+	polar   bool
+	out     io.Writer
+	errOut  io.Writer
+}
+
+func newREPL(out, errOut io.Writer) *repl {
+	s := calculate.NewSession()
+	s.Ans = "ans"
+	return &repl{session: s, out: out, errOut: errOut}
+}
+
+// evaluate runs one line; the session stores a successful result in ans.
+// Function definitions produce no result and report ok == false.
+func (r *repl) evaluate(line string) (v calculate.Value, ok bool, err error) {
+	entry, err := r.session.Eval(line)
+	if err != nil || entry.Def {
+		return v, false, err
+	}
+	return entry.Result, true, nil
+}
+
+// format prints v, in polar form if r.polar is set and v is complex.
+func (r *repl) format(v calculate.Value) string {
+	if r.polar {
+		return v.Polar()
+	}
+	return v.String()
+}
+
+// interactive reads commands and expressions from in line by line until
+// :quit or end of input.
+func (r *repl) interactive(in io.Reader) {
+	r.loop(scanReader{scanner: bufio.NewScanner(in), out: r.out})
+}
+
+// loop runs the session on lines from lines.
+func (r *repl) loop(lines lineReader) {
+	fmt.Fprintln(r.out, "calc: type :help for help, :quit to exit")
+	for {
+		text, err := lines.readLine("> ")
+		if err != nil {
+			if err != io.EOF {
+				fmt.Fprintln(r.errOut, "error:", err)
+			}
+			fmt.Fprintln(r.out)
+			return
+		}
+		line, err := r.expandHistory(strings.TrimSpace(text))
+		if err != nil {
+			fmt.Fprintln(r.errOut, "error:", err)
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			if r.command(line) {
+				return
+			}
+			continue
+		}
+
+		r.history = append(r.history, line)
+		v, ok, err := r.evaluate(line)
+		if err != nil {
+			fmt.Fprintln(r.errOut, "error:", err)
+			continue
+		}
+		if ok {
+			fmt.Fprintln(r.out, r.format(v))
+		}
+	}
+}
+
+// expandHistory replaces !! and !n with the matching history entry.
+func (r *repl) expandHistory(line string) (string, error) {
+	if !strings.HasPrefix(line, "!") {
+		return line, nil
+	}
+	n := len(r.history)
+	if line != "!!" {
+		var err error
+		if n, err = strconv.Atoi(line[1:]); err != nil {
+			return "", fmt.Errorf("bad history reference %q", line)
+		}
+	}
+	if n < 1 || n > len(r.history) {
+		return "", fmt.Errorf("no history entry %s", line)
+	}
+	entry := r.history[n-1]
+	fmt.Fprintln(r.out, entry)
+	return entry, nil
+}
+
+// command handles a :command and reports whether the session should end.
+func (r *repl) command(line string) bool {
+	eval := r.session.Evaluator
+	cmd, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch cmd {
+	case ":quit", ":q", ":exit":
+		return true
+	case ":help", ":h":
+		fmt.Fprint(r.out, helpText)
+	case ":vars":
+		names := make([]string, 0, len(eval.Vars))
+		for name := range eval.Vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(r.out, "%s = %v\n", name, eval.Vars[name])
+		}
+	case ":funcs":
+		names := make([]string, 0, len(eval.Funcs))
+		for name := range eval.Funcs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			f := eval.Funcs[name]
+			def := calculate.FuncDef{Name: name, Params: f.Params, Body: f.Body}
+			fmt.Fprintln(r.out, def.String())
+		}
+	case ":history":
+		for i, entry := range r.history {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, entry)
+		}
+	case ":undo", ":redo":
+		undo := r.session.Undo
+		if cmd == ":redo" {
+			undo = r.session.Redo
+		}
+		names, err := undo()
+		if err != nil {
+			fmt.Fprintln(r.errOut, "error:", err)
+			break
+		}
+		for _, name := range names {
+			if v, ok := eval.Vars[name]; ok {
+				fmt.Fprintf(r.out, "%s = %v\n", name, v)
+			} else {
+				fmt.Fprintf(r.out, "%s is undefined\n", name)
+			}
+		}
+	case ":save", ":load":
+		if arg == "" {
+			fmt.Fprintf(r.errOut, "error: usage: %s FILE\n", cmd)
+			break
+		}
+		if cmd == ":save" {
+			if err := r.session.Save(arg); err != nil {
+				fmt.Fprintln(r.errOut, "error:", err)
+			}
+			break
+		}
+		s, err := calculate.LoadSession(arg)
+		if err != nil {
+			fmt.Fprintln(r.errOut, "error:", err)
+			break
+		}
+		s.Ans = "ans"
+		r.session = s
+		fmt.Fprintf(r.out, "loaded %d entries from %s\n", len(s.History), arg)
+	default:
+		fmt.Fprintf(r.errOut, "error: unknown command %s, try :help\n", line)
+	}
+	return false
+}
+
+// batch evaluates every non-empty line that is not a # comment and prints
+// one result per expression; function definitions print nothing. Errors are
+// reported on errOut with their line number; the count of failed lines is
+// returned.
+func (r *repl) batch(in io.Reader) (int, error) {
+	failed := 0
+	scanner := bufio.NewScanner(in)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		v, ok, err := r.evaluate(line)
+		if err != nil {
+			fmt.Fprintf(r.errOut, "line %d: %v\n", lineNo, err)
+			failed++
+			continue
+		}
+		if ok {
+			fmt.Fprintln(r.out, r.format(v))
+		}
+	}
+	return failed, scanner.Err()
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func main() {
+	exact := flag.Bool("exact", false, "keep division results as exact fractions")
+	caretXor := flag.Bool("xor", false, "read ^ as bitwise xor; ** is power either way")
+	polar := flag.Bool("polar", false, "print complex results as modulus∠argument")
+	divMode := flag.String("divmode", "truncated", "sign convention of % and div/mod: truncated, floored or euclidean")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: calc [-exact] [-xor] [-polar] [-divmode mode] [file]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	r := newREPL(os.Stdout, os.Stderr)
+	r.polar = *polar
+	eval := r.session.Evaluator
+	eval.Exact = *exact
+	eval.CaretXor = *caretXor
+	switch *divMode {
+	case "truncated":
+		eval.DivMode = calculate.DivTruncated
+	case "floored":
+		eval.DivMode = calculate.DivFloored
+	case "euclidean":
+		eval.DivMode = calculate.DivEuclidean
+	default:
+		fmt.Fprintf(os.Stderr, "calc: unknown -divmode %q\n", *divMode)
+		os.Exit(2)
+	}
+	os.Exit(run(r, flag.Args()))
+}
+
+func run(r *repl, args []string) int {
+	in := os.Stdin
+	if len(args) > 0 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "calc:", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	} else if isTerminal(os.Stdin) {
+		if restore, err := makeRaw(os.Stdin); err != nil {
+			r.interactive(os.Stdin)
+		} else {
+			restore()
+			r.loop(&lineEditor{
+				in:      bufio.NewReader(os.Stdin),
+				out:     r.out,
+				history: &r.history,
+				raw:     func() (func(), error) { return makeRaw(os.Stdin) },
+			})
+		}
+		return 0
+	}
+
+	failed, err := r.batch(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "calc:", err)
+		return 1
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	input := "1 + 2\n\n# comment\nx = 4\nx * ans\n1 / 0\nsq(n) = n^2\nsq(2^5)\n"
+	var out, errOut bytes.Buffer
+
+	failed, err := newREPL(&out, &errOut).batch(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("batch error: %v", err)
+	}
+	if expected := "3\n4\n16\n1024\n"; out.String() != expected {
+		t.Errorf("output = %q; expected %q", out.String(), expected)
+	}
+	if failed != 1 || !strings.HasPrefix(errOut.String(), "line 6: ") {
+		t.Errorf("failed = %d, errors = %q; expected one error on line 6", failed, errOut.String())
+	}
+}
+
+func TestBatch_Polar(t *testing.T) {
+	var out, errOut bytes.Buffer
+	r := newREPL(&out, &errOut)
+	r.polar = true
+	if _, err := r.batch(strings.NewReader("sqrt(-4)\n3 + 4i\n2.5\n")); err != nil {
+		t.Fatalf("batch error: %v", err)
+	}
+	if expected := "2∠1.5707963267948966\n5∠0.9272952180016122\n2.5\n"; out.String() != expected {
+		t.Errorf("output = %q; expected %q", out.String(), expected)
+	}
+}
+
+func TestInteractive(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		contains []string
+		errors   string
+	}{
+		{"ans", "2 * 3\nans + 1\n", []string{"> 6\n", "> 7\n"}, ""},
+		{"vars", "x = 2\n:vars\n", []string{"ans = 2\nx = 2\n"}, ""},
+		{"history", "1 + 1\n2 + 2\n!1\n:history\n", []string{"   1  1 + 1\n", "   3  1 + 1\n"}, ""},
+		{"repeat last", "y = 5\n!!\n", []string{"y = 5\n5\n"}, ""},
+		{"help", ":help\n", []string{":quit"}, ""},
+		{"functions", "f(x) = x^2 + 1\nf(3)\n:funcs\n", []string{"> > 10\n", "f(x) = x^2 + 1\n"}, ""},
+		{"error keeps going", "1 +\n3\n", []string{"> 3\n"}, "error: column 4: unexpected end of input\n"},
+		{"unknown command", ":foo\n", nil, "error: unknown command :foo, try :help\n"},
+		{"bad history", "!7\n", nil, "error: no history entry !7\n"},
+		{"undo redo", "x = 1\nx = 2\n:undo\n:undo\n:redo\n",
+			[]string{"> x = 1\nans = 1\n> x is undefined\nans is undefined\n> x = 1\nans = 1\n"}, ""},
+		{"undo restores ans", "2 * 3\n4\n:undo\nans + 1\n", []string{"> ans = 6\n> 7\n"}, ""},
+		{"nothing to undo", ":undo\n", nil, "error: nothing to undo\n"},
+		{"save needs file", ":save\n", nil, "error: usage: :save FILE\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			newREPL(&out, &errOut).interactive(strings.NewReader(tt.input))
+			for _, s := range tt.contains {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("output %q does not contain %q", out.String(), s)
+				}
+			}
+			if errOut.String() != tt.errors {
+				t.Errorf("errors = %q; expected %q", errOut.String(), tt.errors)
+			}
+		})
+	}
+}
+
+func TestInteractive_QuitStopsReading(t *testing.T) {
+	var out, errOut bytes.Buffer
+	newREPL(&out, &errOut).interactive(strings.NewReader(":q\n1 + 1\n"))
+	if strings.Contains(out.String(), "2") {
+		t.Errorf("output %q: input after :q was evaluated", out.String())
+	}
+}
+
+func TestInteractive_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calc.json")
+	var out, errOut bytes.Buffer
+	newREPL(&out, &errOut).interactive(strings.NewReader("r = 3\narea(x) = pi * x^2\n:save " + path + "\n"))
+	if errOut.Len() > 0 {
+		t.Fatalf("errors while saving: %q", errOut.String())
+	}
+
+	out.Reset()
+	newREPL(&out, &errOut).interactive(strings.NewReader(":load " + path + "\nround(area(r))\n"))
+	if errOut.Len() > 0 {
+		t.Fatalf("errors while loading: %q", errOut.String())
+	}
+	if !strings.Contains(out.String(), "loaded 2 entries") || !strings.Contains(out.String(), "> 28\n") {
+		t.Errorf("output = %q; expected the session to be restored", out.String())
+	}
+}
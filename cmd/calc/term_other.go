@@ -0,0 +1,14 @@
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// makeRaw is only implemented for Linux; elsewhere calc reads whole lines
+// without editing.
+func makeRaw(f *os.File) (restore func(), err error) {
+	return nil, errors.New("line editing is not supported on this platform")
+}
@@ -0,0 +1,102 @@
+// Command solve solves equations with package calculate: a linear or
+// polynomial equation in one variable such as "2x + 3 = 11", or a system of
+// linear equations.
+//
+// Equations are taken from the arguments, or one per line from standard
+// input when there are none; a line may hold several separated by ";".
+// Solutions are printed one variable per line, or as JSON with -json.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readEquations splits lines on ";" and drops blank entries and # comments.
+func readEquations(lines []string) []string {
+	var eqs []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, eq := range strings.Split(line, ";") {
+			if eq = strings.TrimSpace(eq); eq != "" {
+				eqs = append(eqs, eq)
+			}
+		}
+	}
+	return eqs
+}
+
+func printText(w io.Writer, r Result) {
+	switch r.Status {
+	case None:
+		fmt.Fprintln(w, "no solution")
+	case Infinite:
+		fmt.Fprintln(w, "infinitely many solutions")
+		for _, name := range r.Variables {
+			if g, ok := r.General[name]; ok {
+				fmt.Fprintf(w, "%s = %s\n", name, g)
+			} else {
+				fmt.Fprintf(w, "%s is free\n", name)
+			}
+		}
+	default:
+		for _, sol := range r.Solutions {
+			for _, name := range r.Variables {
+				fmt.Fprintf(w, "%s = %s\n", name, format(sol[name]))
+			}
+		}
+	}
+}
+
+func main() {
+	asJSON := flag.Bool("json", false, "print the result as JSON")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: solve [-json] [equation ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	os.Exit(run(flag.Args(), os.Stdin, os.Stdout, os.Stderr, *asJSON))
+}
+
+func run(args []string, in io.Reader, out, errOut io.Writer, asJSON bool) int {
+	lines := args
+	if len(lines) == 0 {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			fmt.Fprintln(errOut, "solve:", err)
+			return 1
+		}
+	}
+	eqs := readEquations(lines)
+	if len(eqs) == 0 {
+		fmt.Fprintln(errOut, "solve: no equations")
+		return 2
+	}
+
+	r, err := solve(eqs)
+	if err != nil {
+		fmt.Fprintln(errOut, "solve:", err)
+		return 1
+	}
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			fmt.Fprintln(errOut, "solve:", err)
+			return 1
+		}
+	} else {
+		printText(out, r)
+	}
+	return 0
+}
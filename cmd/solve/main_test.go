@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		json     bool
+		expected string
+		code     int
+	}{
+		{"args", []string{"x + y = 3", "x - y = 1"}, "", false, "x = 2\ny = 1\n", 0},
+		{"stdin", nil, "# system\nx + y = 3; x - y = 1\n\n", false, "x = 2\ny = 1\n", 0},
+		{"quadratic", []string{"x^2 - x = 6"}, "", false, "x = -2\nx = 3\n", 0},
+		{"none", []string{"x = x + 1"}, "", false, "no solution\n", 0},
+		{"infinite", []string{"x + y = 1"}, "", false, "infinitely many solutions\nx = 1 - y\ny is free\n", 0},
+		{"json", []string{"2x + 3 = 11"}, "", true,
+			"{\n  \"status\": \"unique\",\n  \"variables\": [\n    \"x\"\n  ],\n  \"solutions\": [\n    {\n      \"x\": 4\n    }\n  ]\n}\n", 0},
+		{"error", []string{"x +"}, "", false, "", 1},
+		{"json without a number", []string{"1e-320 x = 1"}, "", true, "", 1},
+		{"empty", nil, "\n", false, "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.input), &out, &errOut, tt.json)
+			if code != tt.code {
+				t.Errorf("exit code = %d; expected %d (stderr %q)", code, tt.code, errOut.String())
+			}
+			if out.String() != tt.expected {
+				t.Errorf("output = %q; expected %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang-lessons/calculate"
+	"golang-lessons/calculate/linalg"
+)
+
+var (
+	errNonlinear   = errors.New("not linear")
+	errNoVariables = errors.New("no variables to solve for")
+)
+
+// Status says how many solutions a problem has.
+type Status string
+
+const (
+	Unique   Status = "unique"
+	Multiple Status = "multiple"
+	None     Status = "none"
+	Infinite Status = "infinite"
+)
+
+// Result is the answer to a set of equations. Unique and Multiple list
+// every solution; Infinite lists the Free variables and expresses the
+// others in terms of them in General.
+type Result struct {
+	Status    Status               `json:"status"`
+	Variables []string             `json:"variables"`
+	Solutions []map[string]float64 `json:"solutions,omitempty"`
+	Free      []string             `json:"free,omitempty"`
+	General   map[string]string    `json:"general,omitempty"`
+}
+
+type equation struct {
+	src      string
+	lhs, rhs calculate.Node
+}
+
+func parseEquation(src string) (equation, error) {
+	lhs, rhs, ok := strings.Cut(src, "=")
+	if !ok || strings.Contains(rhs, "=") {
+		return equation{}, fmt.Errorf("%q: expected exactly one =", src)
+	}
+	eq := equation{src: src}
+	var err error
+	if eq.lhs, err = calculate.Parse(lhs); err != nil {
+		return equation{}, fmt.Errorf("%q: left side: %w", src, err)
+	}
+	if eq.rhs, err = calculate.Parse(rhs); err != nil {
+		return equation{}, fmt.Errorf("%q: right side: %w", src, err)
+	}
+	return eq, nil
+}
+
+// solve solves a system of linear equations, or a single polynomial
+// equation in one variable.
+func solve(srcs []string) (Result, error) {
+	eqs := make([]equation, len(srcs))
+	for i, src := range srcs {
+		eq, err := parseEquation(src)
+		if err != nil {
+			return Result{}, err
+		}
+		eqs[i] = eq
+	}
+
+	forms := make([]linear, len(eqs))
+	for i, eq := range eqs {
+		l, err := linearize(eq.lhs)
+		if err == nil {
+			var r linear
+			r, err = linearize(eq.rhs)
+			l = l.add(r, -1)
+		}
+		if errors.Is(err, errNonlinear) && len(eqs) == 1 {
+			return solvePolynomial(eq)
+		}
+		if err != nil {
+			return Result{}, fmt.Errorf("%q: %w", eq.src, err)
+		}
+		forms[i] = l
+	}
+	return solveLinear(forms)
+}
+
+func solvePolynomial(eq equation) (Result, error) {
+	p, err := calculate.ParsePolynomial("(" + eq.lhs.String() + ") - (" + eq.rhs.String() + ")")
+	if err != nil {
+		return Result{}, fmt.Errorf("%q: %w", eq.src, err)
+	}
+	name := variables(eq.lhs, eq.rhs)
+	if len(name) != 1 {
+		return Result{}, fmt.Errorf("%q: %w", eq.src, errNoVariables)
+	}
+	r := Result{Variables: name}
+	switch p.Degree() {
+	case 0, -1:
+		if p.Eval(0) == 0 {
+			r.Status, r.Free = Infinite, name
+		} else {
+			r.Status = None
+		}
+		return r, nil
+	}
+	for _, x := range p.RealRoots(1e-12) {
+		// A root at zero may come out as rounding noise such as -1e-17.
+		// It is zero when the terms in x are noise next to the
+		// coefficients and p(0) is noise next to those terms.
+		var coefs, terms float64
+		for i, c := range p {
+			coefs += math.Abs(c)
+			if i > 0 {
+				terms += math.Abs(c) * math.Pow(math.Abs(x), float64(i))
+			}
+		}
+		if terms <= 1e-12*coefs && math.Abs(p.Eval(0)) <= 1e-12*terms {
+			x = 0
+		}
+		r.Solutions = append(r.Solutions, map[string]float64{name[0]: clean(x)})
+	}
+	switch len(r.Solutions) {
+	case 0:
+		r.Status = None
+	case 1:
+		r.Status = Unique
+	default:
+		r.Status = Multiple
+	}
+	return r, nil
+}
+
+// solveLinear row-reduces the augmented matrix [A|b] of the system. A pivot
+// in the b column means an equation 0 = c; fewer pivots than variables
+// leave the remaining variables free.
+func solveLinear(forms []linear) (Result, error) {
+	seen := map[string]bool{}
+	var names []string
+	for _, l := range forms {
+		for name := range l.coef {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return Result{}, errNoVariables
+	}
+	sort.Strings(names)
+
+	n := len(names)
+	m := linalg.NewMatrix[float64](len(forms), n+1)
+	for i, l := range forms {
+		for j, name := range names {
+			m.Set(i, j, l.coef[name])
+		}
+		m.Set(i, n, -l.c)
+	}
+	rref, pivots := m.RREF()
+
+	r := Result{Variables: names}
+	if len(pivots) > 0 && pivots[len(pivots)-1] == n {
+		r.Status = None
+		return r, nil
+	}
+	if len(pivots) == n {
+		r.Status = Unique
+		values := map[string]float64{}
+		for i, col := range pivots {
+			values[names[col]] = rref.At(i, n)
+		}
+		sol := map[string]float64{}
+		for name, x := range values {
+			if negligible(forms, values, name) {
+				x = 0
+			}
+			sol[name] = clean(x)
+		}
+		r.Solutions = []map[string]float64{sol}
+		return r, nil
+	}
+
+	r.Status = Infinite
+	isPivot := make([]bool, n)
+	for _, col := range pivots {
+		isPivot[col] = true
+	}
+	for j, name := range names {
+		if !isPivot[j] {
+			r.Free = append(r.Free, name)
+		}
+	}
+	// Coefficients of the general solution below 1e-12 are taken as noise.
+	term := func(x float64) float64 {
+		if math.Abs(x) < 1e-12 {
+			return 0
+		}
+		return clean(x)
+	}
+	for i, col := range pivots {
+		var b strings.Builder
+		if c := term(rref.At(i, n)); c != 0 {
+			b.WriteString(format(c))
+		}
+		for j, name := range names {
+			a := term(-rref.At(i, j))
+			if isPivot[j] || a == 0 {
+				continue
+			}
+			switch {
+			case b.Len() == 0 && a < 0:
+				b.WriteString("-")
+			case b.Len() > 0 && a < 0:
+				b.WriteString(" - ")
+			case b.Len() > 0:
+				b.WriteString(" + ")
+			}
+			if a = math.Abs(a); a != 1 {
+				b.WriteString(format(a) + " * ")
+			}
+			b.WriteString(name)
+		}
+		if b.Len() == 0 {
+			b.WriteString("0")
+		}
+		if r.General == nil {
+			r.General = map[string]string{}
+		}
+		r.General[names[col]] = b.String()
+	}
+	return r, nil
+}
+
+// linear is the expression c + Σ coef[name]·name. Variables that cancel
+// keep a zero coefficient so they are still solved for.
+type linear struct {
+	coef map[string]float64
+	c    float64
+}
+
+// add returns l + k·m.
+func (l linear) add(m linear, k float64) linear {
+	r := linear{coef: map[string]float64{}, c: l.c + k*m.c}
+	for name, a := range l.coef {
+		r.coef[name] = a
+	}
+	for name, a := range m.coef {
+		r.coef[name] += k * a
+	}
+	return r
+}
+
+func (l linear) constant() bool {
+	for _, a := range l.coef {
+		if a != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// linearize writes n as a linear form. Subexpressions without variables,
+// such as sqrt(2) or 2^10, are evaluated; a product or quotient must have
+// a constant on one side.
+func linearize(n calculate.Node) (linear, error) {
+	if v, err := calculate.NewEvaluator().EvalNode(n); err == nil {
+		if v.Kind() == calculate.KindComplex {
+			return linear{}, &calculate.EvalError{Column: n.Pos(), Err: fmt.Errorf("%w: %v", calculate.ErrComplex, n)}
+		}
+		return linear{c: v.Float64()}, nil
+	}
+	switch n := n.(type) {
+	case *calculate.Ident:
+		return linear{coef: map[string]float64{n.Name: 1}}, nil
+	case *calculate.UnaryExpr:
+		x, err := linearize(n.X)
+		if err != nil || n.Op == calculate.OpAdd {
+			return x, err
+		}
+		if n.Op == calculate.OpSub {
+			return linear{}.add(x, -1), nil
+		}
+	case *calculate.BinaryExpr:
+		x, err := linearize(n.X)
+		if err != nil {
+			return linear{}, err
+		}
+		y, err := linearize(n.Y)
+		if err != nil {
+			return linear{}, err
+		}
+		switch n.Op {
+		case calculate.OpAdd:
+			return x.add(y, 1), nil
+		case calculate.OpSub:
+			return x.add(y, -1), nil
+		case calculate.OpMul:
+			if y.constant() {
+				return linear{}.add(x, y.c), nil
+			}
+			if x.constant() {
+				return linear{}.add(y, x.c), nil
+			}
+		case calculate.OpDiv:
+			if y.constant() && y.c != 0 {
+				return linear{}.add(x, 1/y.c), nil
+			}
+			if y.constant() {
+				return linear{}, &calculate.EvalError{Column: n.Column, Err: calculate.ErrDivisionByZero}
+			}
+		}
+	}
+	return linear{}, &calculate.EvalError{Column: n.Pos(), Err: fmt.Errorf("%w: %v", errNonlinear, n)}
+}
+
+// variables returns the sorted names in the given trees that are not
+// built-in constants.
+func variables(nodes ...calculate.Node) []string {
+	seen := map[string]bool{}
+	var walk func(calculate.Node)
+	walk = func(n calculate.Node) {
+		switch n := n.(type) {
+		case *calculate.Ident:
+			if _, err := calculate.NewEvaluator().EvalNode(n); err != nil {
+				seen[n.Name] = true
+			}
+		case *calculate.UnaryExpr:
+			walk(n.X)
+		case *calculate.BinaryExpr:
+			walk(n.X)
+			walk(n.Y)
+		case *calculate.CallExpr:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// clean rounds x to the 12 significant digits the output shows, so that
+// rounding noise such as 1.9999999999999998 does not leak out.
+func clean(x float64) float64 {
+	x, _ = strconv.ParseFloat(strconv.FormatFloat(x, 'g', 12, 64), 64)
+	return x
+}
+
+// negligible reports whether the solution for name is rounding noise such
+// as -1e-17 in every equation: its term is below 1e-12 of the terms the
+// equation sums. Solutions are judged against their own equations, not
+// each other, so y = 1e-7 is kept next to x = 1e6.
+func negligible(forms []linear, sol map[string]float64, name string) bool {
+	for _, l := range forms {
+		size := math.Abs(l.c)
+		for v, a := range l.coef {
+			size += math.Abs(a * sol[v])
+		}
+		if t := math.Abs(l.coef[name] * sol[name]); t > 1e-12*size || math.IsInf(t, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func format(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		eqs      []string
+		expected Result
+	}{
+		{"linear", []string{"2x + 3 = 11"},
+			Result{Status: Unique, Variables: []string{"x"}, Solutions: []map[string]float64{{"x": 4}}}},
+		{"both sides", []string{"3(y - 1) = y / 2 + 2"},
+			Result{Status: Unique, Variables: []string{"y"}, Solutions: []map[string]float64{{"y": 2}}}},
+		{"quadratic", []string{"x^2 = 4"},
+			Result{Status: Multiple, Variables: []string{"x"}, Solutions: []map[string]float64{{"x": -2}, {"x": 2}}}},
+		{"factored", []string{"(1 - x)(x + 2) = 0"},
+			Result{Status: Multiple, Variables: []string{"x"}, Solutions: []map[string]float64{{"x": -2}, {"x": 1}}}},
+		{"no real roots", []string{"x^2 + 1 = 0"}, Result{Status: None, Variables: []string{"x"}}},
+		{"contradiction", []string{"x + 1 = x"}, Result{Status: None, Variables: []string{"x"}}},
+		{"identity", []string{"2(x + 1) = 2x + 2"}, Result{Status: Infinite, Variables: []string{"x"}, Free: []string{"x"}}},
+		{"polynomial identity", []string{"x^2 = x * x"}, Result{Status: Infinite, Variables: []string{"x"}, Free: []string{"x"}}},
+		{"system", []string{"x + y = 3", "x - y = 1"},
+			Result{Status: Unique, Variables: []string{"x", "y"}, Solutions: []map[string]float64{{"x": 2, "y": 1}}}},
+		{"constants", []string{"2pi * r = 2pi"},
+			Result{Status: Unique, Variables: []string{"r"}, Solutions: []map[string]float64{{"r": 1}}}},
+		{"inconsistent system", []string{"x + y = 1", "2x + 2y = 3"}, Result{Status: None, Variables: []string{"x", "y"}}},
+		{"underdetermined", []string{"x + 2y - z = 4", "y + z = 1"},
+			Result{Status: Infinite, Variables: []string{"x", "y", "z"}, Free: []string{"z"},
+				General: map[string]string{"x": "2 + 3 * z", "y": "1 - z"}}},
+		{"tiny coefficient", []string{"1e-15 x = 1"},
+			Result{Status: Unique, Variables: []string{"x"}, Solutions: []map[string]float64{{"x": 1e15}}}},
+		{"tiny solution", []string{"1e15 x = 1"},
+			Result{Status: Unique, Variables: []string{"x"}, Solutions: []map[string]float64{{"x": 1e-15}}}},
+		{"solutions of different sizes", []string{"x = 1e6", "y = 1e-7"},
+			Result{Status: Unique, Variables: []string{"x", "y"}, Solutions: []map[string]float64{{"x": 1e6, "y": 1e-7}}}},
+		{"roots of different sizes", []string{"(x - 1e13)(x - 2) = 0"},
+			Result{Status: Multiple, Variables: []string{"x"}, Solutions: []map[string]float64{{"x": 2}, {"x": 1e13}}}},
+		{"root at zero", []string{"x^2 = 3x"},
+			Result{Status: Multiple, Variables: []string{"x"}, Solutions: []map[string]float64{{"x": 0}, {"x": 3}}}},
+		{"noise at zero", []string{"x + y = 2", "x - y = 2"},
+			Result{Status: Unique, Variables: []string{"x", "y"}, Solutions: []map[string]float64{{"x": 2, "y": 0}}}},
+		{"overdetermined", []string{"x + y = 2", "x - y = 0", "2x + y = 3"},
+			Result{Status: Unique, Variables: []string{"x", "y"}, Solutions: []map[string]float64{{"x": 1, "y": 1}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := solve(tt.eqs)
+			if err != nil {
+				t.Fatalf("solve(%q) error: %v", tt.eqs, err)
+			}
+			if !reflect.DeepEqual(r, tt.expected) {
+				t.Errorf("solve(%q) = %+v; expected %+v", tt.eqs, r, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSolve_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		eqs  []string
+		err  error
+	}{
+		{"no equals", []string{"x + 1"}, nil},
+		{"two equals", []string{"x = 1 = 2"}, nil},
+		{"syntax", []string{"x + = 1"}, nil},
+		{"nonlinear system", []string{"x * y = 1", "x = 1"}, errNonlinear},
+		{"two variables", []string{"x^2 = y"}, nil},
+		{"no variables", []string{"1 + 1 = 2"}, errNoVariables},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := solve(tt.eqs)
+			if err == nil {
+				t.Fatalf("solve(%q) succeeded; expected an error", tt.eqs)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("solve(%q) error = %v; expected %v", tt.eqs, err, tt.err)
+			}
+		})
+	}
+}
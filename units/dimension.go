@@ -0,0 +1,126 @@
+// Package units attaches physical dimensions to numbers. A Quantity keeps
+// its value in SI base units together with its Dimension, so conversions
+// are exact up to float64 rounding and adding kilograms to metres is an
+// error rather than a silent bug.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Base is one of the seven SI base dimensions.
+type Base int
+
+const (
+	BaseLength Base = iota
+	BaseMass
+	BaseTime
+	BaseTemperature
+	BaseCurrent
+	BaseAmount
+	BaseLuminosity
+	numBases
+)
+
+var baseSymbols = [numBases]string{"m", "kg", "s", "K", "A", "mol", "cd"}
+
+// Dimension holds the exponent of each base dimension; velocity is
+// length^1 time^-1. The zero value is dimensionless.
+type Dimension [numBases]int8
+
+func dim(b Base) Dimension {
+	var d Dimension
+	d[b] = 1
+	return d
+}
+
+var (
+	Dimensionless Dimension
+	Length        = dim(BaseLength)
+	Mass          = dim(BaseMass)
+	Time          = dim(BaseTime)
+	Temperature   = dim(BaseTemperature)
+	Current       = dim(BaseCurrent)
+	Amount        = dim(BaseAmount)
+	Luminosity    = dim(BaseLuminosity)
+
+	Area         = Length.Mul(Length)
+	Volume       = Area.Mul(Length)
+	Velocity     = Length.Div(Time)
+	Acceleration = Velocity.Div(Time)
+	Force        = Mass.Mul(Acceleration)
+	Energy       = Force.Mul(Length)
+	Power        = Energy.Div(Time)
+	Pressure     = Force.Div(Area)
+	Frequency    = Dimensionless.Div(Time)
+	Charge       = Current.Mul(Time)
+	Voltage      = Power.Div(Current)
+	Capacitance  = Charge.Div(Voltage)
+)
+
+func (d Dimension) Mul(o Dimension) Dimension {
+	for i := range d {
+		d[i] += o[i]
+	}
+	return d
+}
+
+func (d Dimension) Div(o Dimension) Dimension {
+	for i := range d {
+		d[i] -= o[i]
+	}
+	return d
+}
+
+// Pow raises every exponent of d to the n, failing with ErrExponentRange
+// if one no longer fits in an int8.
+func (d Dimension) Pow(n int) (Dimension, error) {
+	for i, exp := range d {
+		if exp == 0 {
+			continue
+		}
+		if n < math.MinInt8 || n > math.MaxInt8 || int(exp)*n < math.MinInt8 || int(exp)*n > math.MaxInt8 {
+			return Dimension{}, fmt.Errorf("%w: (%v)^%d", ErrExponentRange, d, n)
+		}
+		d[i] = exp * int8(n)
+	}
+	return d, nil
+}
+
+func (d Dimension) IsDimensionless() bool {
+	return d == Dimensionless
+}
+
+// String writes d in SI base units, such as "kg/(m·s^2)" for pressure.
+func (d Dimension) String() string {
+	var num, den []string
+	for b, exp := range d {
+		switch {
+		case exp > 0:
+			num = append(num, power(baseSymbols[b], int(exp)))
+		case exp < 0:
+			den = append(den, power(baseSymbols[b], int(-exp)))
+		}
+	}
+	s := strings.Join(num, "·")
+	if len(num) == 0 {
+		s = "1"
+	}
+	switch len(den) {
+	case 0:
+		return s
+	case 1:
+		return s + "/" + den[0]
+	}
+	return s + "/(" + strings.Join(den, "·") + ")"
+}
+
+func power(symbol string, exp int) string {
+	if exp == 1 {
+		return symbol
+	}
+	return symbol + "^" + strconv.Itoa(exp)
+}
@@ -0,0 +1,205 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Quantity is a value with a dimension, stored in SI base units, so 20 °C
+// and 68 °F are the same Quantity. Temperatures read on a scale with an
+// offset are absolute: two of them cannot be added, and subtracting them
+// gives a difference, which In expresses without the offset. Kelvin
+// quantities serve as either.
+type Quantity struct {
+	si   float64
+	dim  Dimension
+	kind tempKind
+}
+
+// tempKind separates absolute temperatures from temperature differences.
+// The order matters: adding takes the larger kind.
+type tempKind int8
+
+const (
+	tempPlain tempKind = iota
+	tempDelta
+	tempAbsolute
+)
+
+// New returns v measured in u.
+func New(v float64, u Unit) Quantity {
+	q := Quantity{si: u.toSI(v), dim: u.Dim}
+	if u.Offset != 0 {
+		q.kind = tempAbsolute
+	}
+	return q
+}
+
+// Scalar returns a dimensionless quantity.
+func Scalar(v float64) Quantity {
+	return Quantity{si: v}
+}
+
+func (q Quantity) Dim() Dimension {
+	return q.dim
+}
+
+// SI returns the value in SI base units.
+func (q Quantity) SI() float64 {
+	return q.si
+}
+
+// In returns the value of q measured in u.
+func (q Quantity) In(u Unit) (float64, error) {
+	if q.dim != u.Dim {
+		return 0, fmt.Errorf("%w: cannot express %v in %s (%v)", ErrDimensionMismatch, q.dim, u, u.Dim)
+	}
+	if q.kind == tempDelta {
+		return q.si / u.Scale, nil
+	}
+	return u.fromSI(q.si), nil
+}
+
+// Convert converts v from one unit to another.
+func Convert(v float64, from, to Unit) (float64, error) {
+	return New(v, from).In(to)
+}
+
+// Add adds quantities of the same dimension. At most one of them may be an
+// absolute temperature, which the sum then is too.
+func (q Quantity) Add(o Quantity) (Quantity, error) {
+	if q.dim != o.dim {
+		return Quantity{}, fmt.Errorf("%w: %v + %v", ErrDimensionMismatch, q.dim, o.dim)
+	}
+	if q.kind == tempAbsolute && o.kind == tempAbsolute {
+		return Quantity{}, fmt.Errorf("%w: %v + %v", ErrAbsoluteTemperature, q, o)
+	}
+	return Quantity{si: q.si + o.si, dim: q.dim, kind: max(q.kind, o.kind)}, nil
+}
+
+// Sub subtracts quantities of the same dimension. The difference of two
+// absolute temperatures is a temperature difference; only an absolute
+// temperature can have one subtracted.
+func (q Quantity) Sub(o Quantity) (Quantity, error) {
+	if q.dim != o.dim {
+		return Quantity{}, fmt.Errorf("%w: %v - %v", ErrDimensionMismatch, q.dim, o.dim)
+	}
+	kind := max(q.kind, o.kind)
+	if o.kind == tempAbsolute {
+		if q.kind != tempAbsolute {
+			return Quantity{}, fmt.Errorf("%w: %v - %v", ErrAbsoluteTemperature, q, o)
+		}
+		kind = tempDelta
+	}
+	return Quantity{si: q.si - o.si, dim: q.dim, kind: kind}, nil
+}
+
+func (q Quantity) Mul(o Quantity) Quantity {
+	p := Quantity{si: q.si * o.si, dim: q.dim.Mul(o.dim)}
+	switch {
+	case o.dim.IsDimensionless():
+		p.kind = q.scaled()
+	case q.dim.IsDimensionless():
+		p.kind = o.scaled()
+	}
+	return p
+}
+
+func (q Quantity) Div(o Quantity) Quantity {
+	p := Quantity{si: q.si / o.si, dim: q.dim.Div(o.dim)}
+	if o.dim.IsDimensionless() {
+		p.kind = q.scaled()
+	}
+	return p
+}
+
+func (q Quantity) Scale(k float64) Quantity {
+	return Quantity{si: q.si * k, dim: q.dim, kind: q.scaled()}
+}
+
+// scaled is the kind of q times a number: a difference stays one, while an
+// absolute temperature becomes plain kelvin.
+func (q Quantity) scaled() tempKind {
+	if q.kind == tempDelta {
+		return tempDelta
+	}
+	return tempPlain
+}
+
+func (q Quantity) Pow(n int) (Quantity, error) {
+	d, err := q.dim.Pow(n)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{si: math.Pow(q.si, float64(n)), dim: d}, nil
+}
+
+// Cmp compares quantities of the same dimension. An absolute temperature
+// does not compare with a temperature difference.
+func (q Quantity) Cmp(o Quantity) (int, error) {
+	if q.dim != o.dim {
+		return 0, fmt.Errorf("%w: %v and %v", ErrDimensionMismatch, q.dim, o.dim)
+	}
+	if min(q.kind, o.kind) == tempDelta && max(q.kind, o.kind) == tempAbsolute {
+		return 0, fmt.Errorf("%w: comparing %v and %v", ErrAbsoluteTemperature, q, o)
+	}
+	switch {
+	case q.si < o.si:
+		return -1, nil
+	case q.si > o.si:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// String formats q in SI base units, as in "9.81 m/s^2".
+func (q Quantity) String() string {
+	s := strconv.FormatFloat(q.si, 'g', -1, 64)
+	if q.dim.IsDimensionless() {
+		return s
+	}
+	return s + " " + q.dim.String()
+}
+
+// Format formats q in the unit u, as in "68 °F".
+func (q Quantity) Format(u Unit) (string, error) {
+	v, err := q.In(u)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64) + " " + u.Symbol, nil
+}
+
+// Parse reads a number followed by a unit accepted by ParseUnit, such as
+// "1.8 m", "100 km/h" or "-40 degF". A bare number is dimensionless.
+func Parse(s string) (Quantity, error) {
+	s = strings.TrimSpace(s)
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.+-eE", r)
+	})
+	if end < 0 {
+		end = len(s)
+	}
+	// Back off to the longest prefix that is a number, in case an "e"
+	// starts the unit rather than an exponent.
+	for ; end > 0; end-- {
+		if _, err := strconv.ParseFloat(s[:end], 64); err == nil {
+			break
+		}
+	}
+	if end == 0 {
+		return Quantity{}, fmt.Errorf("units: missing number in %q", s)
+	}
+	v, _ := strconv.ParseFloat(s[:end], 64)
+	rest := strings.TrimSpace(s[end:])
+	if rest == "" {
+		return Scalar(v), nil
+	}
+	u, err := ParseUnit(rest)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return New(v, u), nil
+}
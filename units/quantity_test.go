@@ -0,0 +1,142 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestQuantity_Arithmetic(t *testing.T) {
+	// Body mass index from Lesson3_HW2: kg / m^2 with height in centimetres.
+	mass := New(70, Kilogram)
+	height, err := New(175, Centimetre).Pow(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bmi := mass.Div(height)
+	if bmi.Dim() != Mass.Div(Area) || math.Abs(bmi.SI()-22.857142857) > 1e-6 {
+		t.Errorf("BMI = %v; expected 22.857 kg/m^2", bmi)
+	}
+
+	total, err := New(1, Mile).Add(New(1, Kilometre))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if km, _ := total.In(Kilometre); math.Abs(km-2.609344) > 1e-12 {
+		t.Errorf("1 mi + 1 km = %v km; expected 2.609344", km)
+	}
+
+	speed := New(100, Kilometre).Div(New(2, Hour))
+	if s, err := speed.Format(mustParseUnit(t, "km/h")); err != nil || s != "50 km/h" {
+		t.Errorf("speed = %q, %v; expected %q", s, err, "50 km/h")
+	}
+
+	s2, _ := New(1, Second).Pow(2)
+	force := New(2, Kilogram).Mul(New(9.81, Metre).Div(s2))
+	if n, err := force.In(Newton); err != nil || math.Abs(n-19.62) > 1e-12 {
+		t.Errorf("force = %v N, %v; expected 19.62", n, err)
+	}
+}
+
+func TestQuantity_DimensionErrors(t *testing.T) {
+	kg, m := New(1, Kilogram), New(1, Metre)
+	if _, err := kg.Add(m); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("kg + m error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := kg.Sub(m); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("kg - m error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := kg.Cmp(m); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Cmp(kg, m) error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := kg.In(Second); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("kg in s error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+	if _, err := m.Pow(200); !errors.Is(err, ErrExponentRange) {
+		t.Errorf("m^200 error = %v; expected %v", err, ErrExponentRange)
+	}
+	if _, err := New(1, Metre).Pow(1 << 40); !errors.Is(err, ErrExponentRange) {
+		t.Errorf("m^(2^40) error = %v; expected %v", err, ErrExponentRange)
+	}
+}
+
+func TestQuantity_Temperature(t *testing.T) {
+	body := New(36.6, Celsius)
+	if f, _ := body.In(Fahrenheit); math.Abs(f-97.88) > 1e-9 {
+		t.Errorf("36.6 °C = %v °F; expected 97.88", f)
+	}
+	if c, _ := New(0, Celsius).Cmp(New(31, Fahrenheit)); c != 1 {
+		t.Errorf("Cmp(0 °C, 31 °F) = %d; expected 1", c)
+	}
+	rise, _ := New(30, Celsius).Sub(New(20, Celsius))
+	if k, _ := rise.In(Kelvin); math.Abs(k-10) > 1e-9 {
+		t.Errorf("30 °C - 20 °C = %v K; expected 10", k)
+	}
+	if c, _ := rise.In(Celsius); math.Abs(c-10) > 1e-9 {
+		t.Errorf("30 °C - 20 °C = %v °C; expected a difference of 10", c)
+	}
+	if f, _ := rise.Scale(2).In(Fahrenheit); math.Abs(f-36) > 1e-9 {
+		t.Errorf("2 × (30 °C - 20 °C) = %v °F; expected a difference of 36", f)
+	}
+
+	warmer, err := New(20, Celsius).Add(rise)
+	if c, _ := warmer.In(Celsius); err != nil || math.Abs(c-30) > 1e-9 {
+		t.Errorf("20 °C + 10 °C difference = %v °C, %v; expected 30", c, err)
+	}
+	cooler, err := New(68, Fahrenheit).Sub(New(10, Kelvin))
+	if c, _ := cooler.In(Celsius); err != nil || math.Abs(c-10) > 1e-9 {
+		t.Errorf("68 °F - 10 K = %v °C, %v; expected 10", c, err)
+	}
+
+	if _, err := New(20, Celsius).Add(New(10, Celsius)); !errors.Is(err, ErrAbsoluteTemperature) {
+		t.Errorf("20 °C + 10 °C error = %v; expected %v", err, ErrAbsoluteTemperature)
+	}
+	if _, err := rise.Sub(New(5, Celsius)); !errors.Is(err, ErrAbsoluteTemperature) {
+		t.Errorf("difference - 5 °C error = %v; expected %v", err, ErrAbsoluteTemperature)
+	}
+	if _, err := rise.Cmp(New(5, Celsius)); !errors.Is(err, ErrAbsoluteTemperature) {
+		t.Errorf("Cmp(difference, 5 °C) error = %v; expected %v", err, ErrAbsoluteTemperature)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.8 m", "1.8 m"},
+		{"100 km/h", "27.77777777777778 m/s"},
+		{"2e3 g", "2 kg"},
+		{"-40 degF", "233.15 K"},
+		{"42", "42"},
+		{"9.81 m/s^2", "9.81 m/s^2"},
+		{"2 C", "2 s·A"},
+		{"1 F/m", "1 s^4·A^2/(m^3·kg)"},
+	}
+
+	for _, tt := range tests {
+		q, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.input, err)
+			continue
+		}
+		if result := q.String(); result != tt.expected {
+			t.Errorf("Parse(%q) = %q; expected %q", tt.input, result, tt.expected)
+		}
+	}
+
+	for _, input := range []string{"m", "3 parsecs", ""} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) succeeded; expected an error", input)
+		}
+	}
+}
+
+func mustParseUnit(t *testing.T, s string) Unit {
+	t.Helper()
+	u, err := ParseUnit(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
@@ -0,0 +1,206 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownUnit       = errors.New("unknown unit")
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+	// ErrAffine reports an attempt to combine a unit with a shifted zero,
+	// such as °C, into a compound unit like °C/s.
+	ErrAffine = errors.New("unit with an offset cannot be combined")
+	// ErrAbsoluteTemperature reports an absolute temperature, such as one
+	// read in °C, where only a difference makes sense: 20 °C + 10 °C.
+	ErrAbsoluteTemperature = errors.New("absolute temperature used as a difference")
+	ErrExponentRange       = errors.New("dimension exponent out of range")
+)
+
+// Unit converts between a number in that unit and SI base units:
+// si = value*Scale + Offset. Only temperature scales have an Offset.
+type Unit struct {
+	Symbol string
+	Dim    Dimension
+	Scale  float64
+	Offset float64
+}
+
+func (u Unit) String() string {
+	return u.Symbol
+}
+
+func (u Unit) toSI(v float64) float64 {
+	return v*u.Scale + u.Offset
+}
+
+func (u Unit) fromSI(si float64) float64 {
+	return (si - u.Offset) / u.Scale
+}
+
+func (u Unit) Mul(o Unit) (Unit, error) {
+	if u.Offset != 0 || o.Offset != 0 {
+		return Unit{}, fmt.Errorf("%w: %s·%s", ErrAffine, u, o)
+	}
+	return Unit{Symbol: u.Symbol + "·" + o.Symbol, Dim: u.Dim.Mul(o.Dim), Scale: u.Scale * o.Scale}, nil
+}
+
+func (u Unit) Div(o Unit) (Unit, error) {
+	if u.Offset != 0 || o.Offset != 0 {
+		return Unit{}, fmt.Errorf("%w: %s/%s", ErrAffine, u, o)
+	}
+	return Unit{Symbol: u.Symbol + "/" + o.Symbol, Dim: u.Dim.Div(o.Dim), Scale: u.Scale / o.Scale}, nil
+}
+
+func (u Unit) Pow(n int) (Unit, error) {
+	if u.Offset != 0 {
+		return Unit{}, fmt.Errorf("%w: %s^%d", ErrAffine, u, n)
+	}
+	d, err := u.Dim.Pow(n)
+	if err != nil {
+		return Unit{}, err
+	}
+	return Unit{Symbol: power(u.Symbol, n), Dim: d, Scale: math.Pow(u.Scale, float64(n))}, nil
+}
+
+func linear(symbol string, d Dimension, scale float64) Unit {
+	return Unit{Symbol: symbol, Dim: d, Scale: scale}
+}
+
+const (
+	inch  = 0.0254
+	pound = 0.45359237
+	litre = 1e-3
+)
+
+var (
+	Metre      = linear("m", Length, 1)
+	Kilometre  = linear("km", Length, 1e3)
+	Centimetre = linear("cm", Length, 1e-2)
+	Millimetre = linear("mm", Length, 1e-3)
+	Inch       = linear("in", Length, inch)
+	Foot       = linear("ft", Length, 12*inch)
+	Yard       = linear("yd", Length, 36*inch)
+	Mile       = linear("mi", Length, 63360*inch)
+
+	Gram     = linear("g", Mass, 1e-3)
+	Kilogram = linear("kg", Mass, 1)
+	Tonne    = linear("t", Mass, 1e3)
+	Pound    = linear("lb", Mass, pound)
+	Ounce    = linear("oz", Mass, pound/16)
+
+	Second = linear("s", Time, 1)
+	Minute = linear("min", Time, 60)
+	Hour   = linear("h", Time, 3600)
+	Day    = linear("d", Time, 86400)
+
+	Kelvin     = linear("K", Temperature, 1)
+	Celsius    = Unit{Symbol: "°C", Dim: Temperature, Scale: 1, Offset: 273.15}
+	Fahrenheit = Unit{Symbol: "°F", Dim: Temperature, Scale: 5.0 / 9, Offset: 273.15 - 32*5.0/9}
+	Rankine    = linear("°R", Temperature, 5.0/9)
+
+	Litre      = linear("L", Volume, litre)
+	Millilitre = linear("mL", Volume, litre/1000)
+	Gallon     = linear("gal", Volume, 231*inch*inch*inch) // US liquid gallon
+
+	Ampere  = linear("A", Current, 1)
+	Mole    = linear("mol", Amount, 1)
+	Candela = linear("cd", Luminosity, 1)
+	Coulomb = linear("C", Charge, 1)
+	Volt    = linear("V", Voltage, 1)
+	Farad   = linear("F", Capacitance, 1)
+
+	Newton = linear("N", Force, 1)
+	Joule  = linear("J", Energy, 1)
+	Watt   = linear("W", Power, 1)
+	Pascal = linear("Pa", Pressure, 1)
+	Hertz  = linear("Hz", Frequency, 1)
+)
+
+var registry = map[string]Unit{}
+
+func init() {
+	for _, u := range []Unit{
+		Metre, Kilometre, Centimetre, Millimetre, Inch, Foot, Yard, Mile,
+		Gram, Kilogram, Tonne, Pound, Ounce,
+		Second, Minute, Hour, Day,
+		Kelvin, Celsius, Fahrenheit, Rankine,
+		Litre, Millilitre, Gallon,
+		Ampere, Mole, Candela, Coulomb, Volt, Farad,
+		Newton, Joule, Watt, Pascal, Hertz,
+	} {
+		registry[u.Symbol] = u
+	}
+	for alias, symbol := range map[string]string{
+		"degC": "°C", "degF": "°F", "degR": "°R", "R": "°R",
+		"l": "L", "ml": "mL", "sec": "s", "hr": "h",
+	} {
+		registry[alias] = registry[symbol]
+	}
+}
+
+// Lookup returns the unit with the given symbol. Temperature scales are
+// also known as degC, degF and degR; plain C and F are coulomb and farad.
+func Lookup(symbol string) (Unit, error) {
+	u, ok := registry[symbol]
+	if !ok {
+		return Unit{}, fmt.Errorf("%w %q", ErrUnknownUnit, symbol)
+	}
+	return u, nil
+}
+
+// ParseUnit parses a compound unit such as "kg*m/s^2" or "km/h". Every
+// unit after a "/" divides, and "·" may be used for "*".
+func ParseUnit(s string) (Unit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Unit{}, fmt.Errorf("%w: empty", ErrUnknownUnit)
+	}
+	num, den, _ := strings.Cut(strings.ReplaceAll(s, "·", "*"), "/")
+	var result Unit
+	for i, part := range strings.Split(num, "*") {
+		u, err := parseFactor(part)
+		if err != nil {
+			return Unit{}, err
+		}
+		if i == 0 {
+			result = u
+		} else if result, err = result.Mul(u); err != nil {
+			return Unit{}, err
+		}
+	}
+	if den == "" {
+		return result, nil
+	}
+	for _, part := range strings.FieldsFunc(den, func(r rune) bool { return r == '*' || r == '/' }) {
+		u, err := parseFactor(part)
+		if err != nil {
+			return Unit{}, err
+		}
+		if result, err = result.Div(u); err != nil {
+			return Unit{}, err
+		}
+	}
+	return result, nil
+}
+
+// parseFactor parses a single unit with an optional integer power, "s^2".
+func parseFactor(s string) (Unit, error) {
+	s = strings.TrimSpace(s)
+	if s == "1" {
+		return linear("1", Dimensionless, 1), nil
+	}
+	symbol, exp, hasExp := strings.Cut(s, "^")
+	u, err := Lookup(strings.TrimSpace(symbol))
+	if err != nil || !hasExp {
+		return u, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(exp))
+	if err != nil {
+		return Unit{}, fmt.Errorf("%w %q: bad exponent", ErrUnknownUnit, s)
+	}
+	return u.Pow(n)
+}
@@ -0,0 +1,102 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		from, to Unit
+		expected float64
+	}{
+		{"celsius to fahrenheit", 100, Celsius, Fahrenheit, 212},
+		{"fahrenheit to celsius", -40, Fahrenheit, Celsius, -40},
+		{"celsius to kelvin", 0, Celsius, Kelvin, 273.15},
+		{"rankine to fahrenheit", 491.67, Rankine, Fahrenheit, 32},
+		{"kelvin to rankine", 100, Kelvin, Rankine, 180},
+		{"centimetres to metres", 180, Centimetre, Metre, 1.8},
+		{"miles to kilometres", 1, Mile, Kilometre, 1.609344},
+		{"feet to inches", 3, Foot, Inch, 36},
+		{"pounds to kilograms", 1, Pound, Kilogram, 0.45359237},
+		{"ounces to grams", 16, Ounce, Gram, 453.59237},
+		{"hours to seconds", 1.5, Hour, Second, 5400},
+		{"gallons to litres", 1, Gallon, Litre, 3.785411784},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Convert(tt.value, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Convert error: %v", err)
+			}
+			if math.Abs(result-tt.expected) > 1e-9*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("Convert(%v %s to %s) = %v; expected %v", tt.value, tt.from, tt.to, result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Convert(1, Kilogram, Metre); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Convert(kg to m) error = %v; expected %v", err, ErrDimensionMismatch)
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		input string
+		dim   Dimension
+		scale float64
+		err   error
+	}{
+		{"m", Length, 1, nil},
+		{"km/h", Velocity, 1000.0 / 3600, nil},
+		{"kg*m/s^2", Force, 1, nil},
+		{"kg·m^2/s^2", Energy, 1, nil},
+		{"N/m^2", Pressure, 1, nil},
+		{"m/s/s", Acceleration, 1, nil},
+		{"1/min", Frequency, 1.0 / 60, nil},
+		{"degF", Temperature, 5.0 / 9, nil},
+		{"furlong", Dimension{}, 0, ErrUnknownUnit},
+		{"m^x", Dimension{}, 0, ErrUnknownUnit},
+		{"°C/s", Dimension{}, 0, ErrAffine},
+		{"C/s", Current, 1, nil},
+		{"m^128", Dimension{}, 0, ErrExponentRange},
+		{"", Dimension{}, 0, ErrUnknownUnit},
+	}
+
+	for _, tt := range tests {
+		u, err := ParseUnit(tt.input)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("ParseUnit(%q) error = %v; expected %v", tt.input, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || u.Dim != tt.dim || math.Abs(u.Scale-tt.scale) > 1e-12 {
+			t.Errorf("ParseUnit(%q) = %+v, %v; expected %v scale %v", tt.input, u, err, tt.dim, tt.scale)
+		}
+	}
+}
+
+func TestDimension_String(t *testing.T) {
+	tests := []struct {
+		dim      Dimension
+		expected string
+	}{
+		{Dimensionless, "1"},
+		{Length, "m"},
+		{Velocity, "m/s"},
+		{Force, "m·kg/s^2"},
+		{Pressure, "kg/(m·s^2)"},
+		{Frequency, "1/s"},
+	}
+
+	for _, tt := range tests {
+		if result := tt.dim.String(); result != tt.expected {
+			t.Errorf("String() = %q; expected %q", result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package utils
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Graphemes splits s into extended grapheme clusters as defined by Unicode
+// UAX #29: user-perceived characters such as "й" written as и plus a
+// combining breve, an emoji with a skin tone, a ZWJ sequence or a flag.
+func Graphemes(s string) []string {
+	var clusters []string
+	for len(s) > 0 {
+		n := graphemeLen(s)
+		clusters = append(clusters, s[:n])
+		s = s[n:]
+	}
+	return clusters
+}
+
+// ReverseGraphemes reverses s cluster by cluster, so combining marks stay
+// on their letters and emoji sequences stay whole, unlike Reverse.
+func ReverseGraphemes(s string) string {
+	clusters := Graphemes(s)
+	for i, j := 0, len(clusters)-1; i < j; i, j = i+1, j-1 {
+		clusters[i], clusters[j] = clusters[j], clusters[i]
+	}
+	b := make([]byte, 0, len(s))
+	for _, c := range clusters {
+		b = append(b, c...)
+	}
+	return string(b)
+}
+
+// gcb is the Grapheme_Cluster_Break property of a rune.
+type gcb int
+
+const (
+	gcbOther gcb = iota
+	gcbCR
+	gcbLF
+	gcbControl
+	gcbExtend
+	gcbZWJ
+	gcbRegionalIndicator
+	gcbPrepend
+	gcbSpacingMark
+	gcbL
+	gcbV
+	gcbT
+	gcbLV
+	gcbLVT
+)
+
+// graphemeLen returns the length in bytes of the first grapheme cluster of
+// s, applying the rules GB3 to GB999 of UAX #29.
+func graphemeLen(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	prev := graphemeBreak(r)
+
+	// State for the rules that look further back than one rune: GB9c
+	// (Indic conjuncts), GB11 (emoji ZWJ sequences) and GB12/13 (flags).
+	conjunct, linked := incbConsonant(r), false
+	pictographic := unicode.Is(extendedPictographic, r)
+	regional := 0
+	if prev == gcbRegionalIndicator {
+		regional = 1
+	}
+
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		next := graphemeBreak(r)
+		join := false
+		switch {
+		case prev == gcbCR && next == gcbLF:
+			join = true
+		case prev == gcbCR, prev == gcbLF, prev == gcbControl,
+			next == gcbCR, next == gcbLF, next == gcbControl:
+		case prev == gcbL && (next == gcbL || next == gcbV || next == gcbLV || next == gcbLVT),
+			(prev == gcbLV || prev == gcbV) && (next == gcbV || next == gcbT),
+			(prev == gcbLVT || prev == gcbT) && next == gcbT:
+			join = true
+		case next == gcbExtend, next == gcbZWJ, next == gcbSpacingMark, prev == gcbPrepend:
+			join = true
+		case conjunct && linked && incbConsonant(r):
+			join = true
+		case pictographic && prev == gcbZWJ && unicode.Is(extendedPictographic, r):
+			join = true
+		case regional%2 == 1 && next == gcbRegionalIndicator:
+			join = true
+		}
+		if !join {
+			break
+		}
+
+		switch {
+		case incbConsonant(r):
+			conjunct, linked = true, false
+		case incbLinker(r):
+			linked = linked || conjunct
+		case next != gcbExtend && next != gcbZWJ:
+			conjunct, linked = false, false
+		}
+		switch {
+		case unicode.Is(extendedPictographic, r):
+			pictographic = true
+		case (next == gcbExtend || next == gcbZWJ) && prev != gcbZWJ:
+		default:
+			pictographic = false
+		}
+		if next == gcbRegionalIndicator {
+			regional++
+		} else {
+			regional = 0
+		}
+		prev = next
+		n += size
+	}
+	return n
+}
+
+func graphemeBreak(r rune) gcb {
+	switch {
+	case r == '\r':
+		return gcbCR
+	case r == '\n':
+		return gcbLF
+	case r == 0x200D:
+		return gcbZWJ
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return gcbL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return gcbV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return gcbT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		// Precomposed syllables: every 28th one has no final consonant.
+		if (r-0xAC00)%28 == 0 {
+			return gcbLV
+		}
+		return gcbLVT
+	case unicode.Is(unicode.Regional_Indicator, r):
+		return gcbRegionalIndicator
+	case unicode.Is(unicode.Prepended_Concatenation_Mark, r), unicode.Is(prepend, r):
+		return gcbPrepend
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend),
+		r == 0x200C, r >= 0x1F3FB && r <= 0x1F3FF:
+		return gcbExtend
+	case r == 0x0E33, r == 0x0EB3,
+		unicode.Is(unicode.Mc, r) && !unicode.Is(notSpacingMark, r):
+		return gcbSpacingMark
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gcbControl
+	}
+	return gcbOther
+}
+
+// incbConsonant and incbLinker give the Indic_Conjunct_Break property for
+// the scripts where a virama joins consonants into one cluster (GB9c).
+func incbConsonant(r rune) bool {
+	return unicode.Is(incbConsonants, r)
+}
+
+func incbLinker(r rune) bool {
+	switch r {
+	case 0x094D, 0x09CD, 0x0ACD, 0x0B4D, 0x0C4D, 0x0D4D:
+		return true
+	}
+	return false
+}
+
+var incbConsonants = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0915, 0x0939, 1}, {0x0958, 0x095F, 1}, {0x0978, 0x097F, 1},
+		{0x0995, 0x09A8, 1}, {0x09AA, 0x09B0, 1}, {0x09B2, 0x09B6, 4}, {0x09B7, 0x09B9, 1},
+		{0x09DC, 0x09DD, 1}, {0x09DF, 0x09F0, 17}, {0x09F1, 0x09F1, 1},
+		{0x0A95, 0x0AA8, 1}, {0x0AAA, 0x0AB0, 1}, {0x0AB2, 0x0AB3, 1}, {0x0AB5, 0x0AB9, 1},
+		{0x0AF9, 0x0AF9, 1},
+		{0x0B15, 0x0B28, 1}, {0x0B2A, 0x0B30, 1}, {0x0B32, 0x0B33, 1}, {0x0B35, 0x0B39, 1},
+		{0x0B5C, 0x0B5D, 1}, {0x0B5F, 0x0B71, 18},
+		{0x0C15, 0x0C28, 1}, {0x0C2A, 0x0C39, 1}, {0x0C58, 0x0C5A, 1},
+		{0x0D15, 0x0D3A, 1},
+	},
+}
+
+// prepend lists the Prepend characters that are not prepended
+// concatenation marks.
+var prepend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0D4E, 0x0D4E, 1},
+	},
+	R32: []unicode.Range32{
+		{0x111C2, 0x111C3, 1}, {0x1193F, 0x11941, 2}, {0x11A3A, 0x11A84, 74},
+		{0x11A85, 0x11A89, 1}, {0x11D46, 0x11F02, 444},
+	},
+}
+
+// notSpacingMark lists the spacing combining marks (Mc) that UAX #29 does
+// not treat as SpacingMark, mostly Myanmar vowel signs.
+var notSpacingMark = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x102B, 0x102C, 1}, {0x1038, 0x1038, 1}, {0x1062, 0x1064, 1}, {0x1067, 0x106D, 1},
+		{0x1083, 0x1087, 4}, {0x1088, 0x108C, 1}, {0x108F, 0x108F, 1}, {0x109A, 0x109C, 1},
+		{0x1A61, 0x1A63, 2}, {0x1A64, 0x1A64, 1}, {0xAA7B, 0xAA7D, 2},
+	},
+	R32: []unicode.Range32{
+		{0x11720, 0x11721, 1},
+	},
+}
+
+// extendedPictographic is the Extended_Pictographic property from the
+// Unicode emoji data, which package unicode does not provide.
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00A9, 0x00AE, 5}, {0x203C, 0x2049, 13}, {0x2122, 0x2139, 23},
+		{0x2194, 0x2199, 1}, {0x21A9, 0x21AA, 1}, {0x231A, 0x231B, 1}, {0x2328, 0x2388, 96},
+		{0x23CF, 0x23CF, 1}, {0x23E9, 0x23F3, 1}, {0x23F8, 0x23FA, 1}, {0x24C2, 0x24C2, 1},
+		{0x25AA, 0x25AB, 1}, {0x25B6, 0x25C0, 10}, {0x25FB, 0x25FE, 1},
+		{0x2600, 0x2605, 1}, {0x2607, 0x2612, 1}, {0x2614, 0x2685, 1}, {0x2690, 0x2705, 1},
+		{0x2708, 0x2712, 1}, {0x2714, 0x2716, 2}, {0x271D, 0x2721, 4}, {0x2728, 0x2733, 11},
+		{0x2734, 0x2744, 16}, {0x2747, 0x274C, 5}, {0x274E, 0x2753, 5}, {0x2754, 0x2755, 1},
+		{0x2757, 0x2757, 1}, {0x2763, 0x2767, 1}, {0x2795, 0x2797, 1}, {0x27A1, 0x27B0, 15},
+		{0x27BF, 0x27BF, 1}, {0x2934, 0x2935, 1}, {0x2B05, 0x2B07, 1}, {0x2B1B, 0x2B1C, 1},
+		{0x2B50, 0x2B55, 5}, {0x3030, 0x303D, 13}, {0x3297, 0x3299, 2},
+	},
+	R32: []unicode.Range32{
+		{0x1F000, 0x1F0FF, 1}, {0x1F10D, 0x1F10F, 1}, {0x1F12F, 0x1F12F, 1},
+		{0x1F16C, 0x1F171, 1}, {0x1F17E, 0x1F17F, 1}, {0x1F18E, 0x1F18E, 1},
+		{0x1F191, 0x1F19A, 1}, {0x1F1AD, 0x1F1E5, 1}, {0x1F201, 0x1F20F, 1},
+		{0x1F21A, 0x1F22F, 21}, {0x1F232, 0x1F23A, 1}, {0x1F23C, 0x1F23F, 1},
+		{0x1F249, 0x1F3FA, 1}, {0x1F400, 0x1F53D, 1}, {0x1F546, 0x1F64F, 1},
+		{0x1F680, 0x1F6FF, 1}, {0x1F774, 0x1F77F, 1}, {0x1F7D5, 0x1F7FF, 1},
+		{0x1F80C, 0x1F80F, 1}, {0x1F848, 0x1F84F, 1}, {0x1F85A, 0x1F85F, 1},
+		{0x1F888, 0x1F88F, 1}, {0x1F8AE, 0x1F8FF, 1}, {0x1F90C, 0x1F93A, 1},
+		{0x1F93C, 0x1F945, 1}, {0x1F947, 0x1FAFF, 1}, {0x1FC00, 0x1FFFD, 1},
+	},
+}
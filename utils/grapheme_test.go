@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty string", "", nil},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"combining breve", "и\u0306ка", []string{"и\u0306", "к", "а"}},
+		{"stacked marks", "е\u0308\u0301!", []string{"е\u0308\u0301", "!"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"mark after newline", "\n\u0301", []string{"\n", "\u0301"}},
+		{"skin tone", "👍🏽👍", []string{"👍🏽", "👍"}},
+		{"zwj family", "👨\u200d👩\u200d👧x", []string{"👨\u200d👩\u200d👧", "x"}},
+		{"zwj with variation selector", "❤\ufe0f\u200d🔥", []string{"❤\ufe0f\u200d🔥"}},
+		{"zwj without emoji", "a\u200db", []string{"a\u200d", "b"}},
+		{"flags", "🇷🇺🇹🇯", []string{"🇷🇺", "🇹🇯"}},
+		{"odd regional indicator", "🇷🇺🇹", []string{"🇷🇺", "🇹"}},
+		{"hangul jamo", "\u1100\u1161\u11a8\uac00", []string{"\u1100\u1161\u11a8", "\uac00"}},
+		{"devanagari conjunct", "क\u094dष\u093f", []string{"क\u094dष\u093f"}},
+		{"spacing mark", "क\u093e", []string{"क\u093e"}},
+		{"prepend", "\u0600١", []string{"\u0600١"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Graphemes(tt.input)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Graphemes(%q) = %q; expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReverseGraphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty string", "", ""},
+		{"unicode string", "привет", "тевирп"},
+		{"decomposed й", "и\u0306од", "дои\u0306"},
+		{"decomposed ё", "е\u0308лка", "акле\u0308"},
+		{"emoji with skin tone", "hi 👋\U0001f3ff!", "!👋\U0001f3ff ih"},
+		{"zwj sequence", "a👩\u200d💻b", "b👩\u200d💻a"},
+		{"flags", "🇹🇯🇷🇺", "🇷🇺🇹🇯"},
+		{"crlf kept in order", "a\r\nb", "b\r\na"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ReverseGraphemes(tt.input)
+			if result != tt.expected {
+				t.Errorf("ReverseGraphemes(%q) = %q; expected %q",
+					tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// LetterClass is the role a letter plays in a language's alphabet.
+type LetterClass int
+
+const (
+	LetterOther LetterClass = iota
+	LetterVowel
+	LetterConsonant
+)
+
+func (c LetterClass) String() string {
+	switch c {
+	case LetterOther:
+		return "other"
+	case LetterVowel:
+		return "vowel"
+	case LetterConsonant:
+		return "consonant"
+	}
+	return "LetterClass(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Language describes the alphabet of a language by its lower-case letters.
+// Signs are letters that are neither vowels nor consonants, such as the
+// Russian ъ and ь. Letters in WordInitialConsonants are consonants at the
+// start of a word and vowels elsewhere, like the English y in "yes" and
+// "myth".
+type Language struct {
+	Code   string
+	Name   string
+	Script *unicode.RangeTable
+
+	Vowels                string
+	Consonants            string
+	Signs                 string
+	WordInitialConsonants string
+
+	// Case lowers letters before they are looked up, as Turkish maps I to ı;
+	// nil means the standard mapping.
+	Case unicode.SpecialCase
+}
+
+var (
+	English = &Language{
+		Code: "en", Name: "English", Script: unicode.Latin,
+		Vowels:                "aeiou",
+		Consonants:            "bcdfghjklmnpqrstvwxz",
+		WordInitialConsonants: "y",
+	}
+	Russian = &Language{
+		Code: "ru", Name: "Russian", Script: unicode.Cyrillic,
+		Vowels:     "аеёиоуыэюя",
+		Consonants: "бвгджзйклмнпрстфхцчшщ",
+		Signs:      "ъь",
+	}
+	Ukrainian = &Language{
+		Code: "uk", Name: "Ukrainian", Script: unicode.Cyrillic,
+		Vowels:     "аеєиіїоуюя",
+		Consonants: "бвгґджзйклмнпрстфхцчшщ",
+		Signs:      "ь'’",
+	}
+	Tajik = &Language{
+		Code: "tg", Name: "Tajik", Script: unicode.Cyrillic,
+		Vowels:     "аеёиӣоуӯэюя",
+		Consonants: "бвгғджзйкқлмнпрстфхҳчҷш",
+		Signs:      "ъ",
+	}
+	German = &Language{
+		Code: "de", Name: "German", Script: unicode.Latin,
+		Vowels:     "aeiouyäöü",
+		Consonants: "bcdfghjklmnpqrstvwxzß",
+	}
+	French = &Language{
+		Code: "fr", Name: "French", Script: unicode.Latin,
+		Vowels:     "aeiouyàâæéèêëîïôœùûüÿ",
+		Consonants: "bcdfghjklmnpqrstvwxzç",
+	}
+	Turkish = &Language{
+		Code: "tr", Name: "Turkish", Script: unicode.Latin,
+		Vowels:     "aeıioöuü",
+		Consonants: "bcçdfgğhjklmnprsştvyz",
+		Case:       unicode.TurkishCase,
+	}
+)
+
+var (
+	languagesMu sync.RWMutex
+	languages   []*Language
+)
+
+func init() {
+	for _, l := range []*Language{English, Russian, Ukrainian, Tajik, German, French, Turkish} {
+		RegisterLanguage(l)
+	}
+}
+
+// RegisterLanguage adds l to the registry, replacing any language with the
+// same code. Earlier languages win ties in DetectLanguage.
+func RegisterLanguage(l *Language) {
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+	for i, old := range languages {
+		if old.Code == l.Code {
+			languages[i] = l
+			return
+		}
+	}
+	languages = append(languages, l)
+}
+
+// LookupLanguage returns the registered language with the given code.
+func LookupLanguage(code string) (*Language, error) {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	for _, l := range languages {
+		if l.Code == code {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownLanguage, code)
+}
+
+// Languages returns the registered languages in registration order.
+func Languages() []*Language {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	return append([]*Language(nil), languages...)
+}
+
+func (l *Language) lower(r rune) rune {
+	if l.Case != nil {
+		return l.Case.ToLower(r)
+	}
+	return unicode.ToLower(r)
+}
+
+// Classify returns the class of r within a word.
+func (l *Language) Classify(r rune) LetterClass {
+	return l.classify(r, false)
+}
+
+func (l *Language) classify(r rune, wordStart bool) LetterClass {
+	r = l.lower(r)
+	switch {
+	case strings.ContainsRune(l.WordInitialConsonants, r):
+		if wordStart {
+			return LetterConsonant
+		}
+		return LetterVowel
+	case strings.ContainsRune(l.Vowels, r):
+		return LetterVowel
+	case strings.ContainsRune(l.Consonants, r):
+		return LetterConsonant
+	}
+	return LetterOther
+}
+
+// knows reports whether r belongs to the alphabet of l.
+func (l *Language) knows(r rune) bool {
+	return l.Classify(r) != LetterOther || strings.ContainsRune(l.Signs, l.lower(r))
+}
+
+// DetectLanguage returns the registered language whose alphabet covers
+// the most letters of s, or nil if none covers any. Ties go to the
+// language registered first, so plain Latin text is English and plain
+// Cyrillic text Russian.
+func DetectLanguage(s string) *Language {
+	var best *Language
+	bestScore := 0
+	for _, l := range Languages() {
+		score := 0
+		for _, r := range s {
+			if unicode.Is(l.Script, r) && l.knows(r) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = l, score
+		}
+	}
+	return best
+}
+
+// CountVowelsIn counts the vowels of s in lang. With a nil lang each word
+// is counted in the language DetectLanguage picks for it, so mixed text
+// such as "hello мир" and words with letters like ғ or ї are handled.
+// Decomposed letters are composed first.
+func CountVowelsIn(s string, lang *Language) int {
+	return countClass(s, lang, LetterVowel)
+}
+
+// CountConsonants is CountVowelsIn for consonants.
+func CountConsonants(s string, lang *Language) int {
+	return countClass(s, lang, LetterConsonant)
+}
+
+func countClass(s string, lang *Language, class LetterClass) int {
+	count := 0
+	for _, word := range strings.FieldsFunc(NFC(s), isWordBreak) {
+		l := lang
+		if l == nil {
+			if l = DetectLanguage(word); l == nil {
+				continue
+			}
+		}
+		for i, r := range []rune(word) {
+			if l.classify(r, i == 0) == class {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// isWordBreak splits words at anything but letters, marks and the
+// apostrophes Ukrainian spells with.
+func isWordBreak(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsMark(r) && r != '\'' && r != '’'
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLanguage_Classify(t *testing.T) {
+	tests := []struct {
+		lang     *Language
+		r        rune
+		expected LetterClass
+	}{
+		{English, 'A', LetterVowel},
+		{English, 'y', LetterVowel},
+		{English, 'k', LetterConsonant},
+		{English, 'ü', LetterOther},
+		{Russian, 'Ё', LetterVowel},
+		{Russian, 'й', LetterConsonant},
+		{Russian, 'ь', LetterOther},
+		{Ukrainian, 'ї', LetterVowel},
+		{Ukrainian, 'ґ', LetterConsonant},
+		{Tajik, 'ӯ', LetterVowel},
+		{Tajik, 'Ҷ', LetterConsonant},
+		{German, 'ß', LetterConsonant},
+		{French, 'œ', LetterVowel},
+		{Turkish, 'I', LetterVowel},
+		{Turkish, 'y', LetterConsonant},
+	}
+
+	for _, tt := range tests {
+		if result := tt.lang.Classify(tt.r); result != tt.expected {
+			t.Errorf("%s.Classify(%q) = %v; expected %v", tt.lang.Name, tt.r, result, tt.expected)
+		}
+	}
+}
+
+func TestCountVowelsIn(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              string
+		lang               *Language
+		vowels, consonants int
+	}{
+		{"english", "hello world", English, 3, 7},
+		{"y as consonant and vowel", "yes, myth", English, 2, 5},
+		{"russian", "привет мир", Russian, 3, 6},
+		{"russian signs", "объём", Russian, 2, 2},
+		{"tajik", "Ҳафта ғазал", Tajik, 4, 6},
+		{"ukrainian apostrophe", "м'ята їжак", Ukrainian, 4, 4},
+		{"german", "Größe", German, 2, 3},
+		{"turkish dotless i", "IŞIK", Turkish, 2, 2},
+		{"decomposed", "и\u0306од", Russian, 1, 2},
+		{"auto mixed", "hello мир", nil, 3, 5},
+		{"auto tajik", "ҷаҳон", nil, 2, 3},
+		{"auto french", "élève", nil, 3, 2},
+		{"auto nothing known", "123 漢字", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := CountVowelsIn(tt.input, tt.lang); result != tt.vowels {
+				t.Errorf("CountVowelsIn(%q) = %d; expected %d", tt.input, result, tt.vowels)
+			}
+			if result := CountConsonants(tt.input, tt.lang); result != tt.consonants {
+				t.Errorf("CountConsonants(%q) = %d; expected %d", tt.input, result, tt.consonants)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *Language
+	}{
+		{"hello", English},
+		{"Straße", German},
+		{"привет", Russian},
+		{"ғазал", Tajik},
+		{"їжак", Ukrainian},
+		{"ışık", Turkish},
+		{"123", nil},
+	}
+
+	for _, tt := range tests {
+		if result := DetectLanguage(tt.input); result != tt.expected {
+			t.Errorf("DetectLanguage(%q) = %v; expected %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestLanguageRegistry(t *testing.T) {
+	if l, err := LookupLanguage("tg"); err != nil || l != Tajik {
+		t.Errorf("LookupLanguage(tg) = %v, %v; expected Tajik", l, err)
+	}
+	if _, err := LookupLanguage("xx"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("LookupLanguage(xx) error = %v; expected %v", err, ErrUnknownLanguage)
+	}
+	if n := len(Languages()); n < 7 {
+		t.Errorf("len(Languages()) = %d; expected at least 7", n)
+	}
+}
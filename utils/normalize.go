@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Form is a Unicode normalization form.
+type Form int
+
+const (
+	// FormNone leaves text as it is.
+	FormNone Form = iota
+	// FormNFC composes letters and marks into precomposed runes.
+	FormNFC
+	// FormNFD decomposes precomposed runes into letters and marks.
+	FormNFD
+)
+
+// Normalize returns s in the given form.
+func Normalize(s string, form Form) string {
+	switch form {
+	case FormNFC:
+		return NFC(s)
+	case FormNFD:
+		return NFD(s)
+	}
+	return s
+}
+
+// NFD returns the canonical decomposition of s, so that "й" and "и" followed
+// by U+0306 compare equal. It covers Latin, basic Greek, Cyrillic and
+// Hangul; runes of other scripts are left as they are.
+func NFD(s string) string {
+	var b []rune
+	for _, r := range s {
+		b = decompose(b, r)
+	}
+	// Canonical ordering: sort each run of combining marks by class.
+	for i := 0; i < len(b); {
+		if combiningClass(b[i]) == 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(b) && combiningClass(b[j]) != 0 {
+			j++
+		}
+		marks := b[i:j]
+		sort.SliceStable(marks, func(x, y int) bool {
+			return combiningClass(marks[x]) < combiningClass(marks[y])
+		})
+		i = j
+	}
+	return string(b)
+}
+
+// NFC returns the canonical composition of s: NFD followed by combining
+// every letter with the marks that have a precomposed form.
+func NFC(s string) string {
+	d := []rune(NFD(s))
+	out := d[:0]
+	starter := -1 // index in out of the last starter
+	var last uint8
+	for _, r := range d {
+		class := combiningClass(r)
+		if starter >= 0 && (last < class || last == 0 && len(out)-1 == starter) {
+			if c, ok := compose(out[starter], r); ok {
+				out[starter] = c
+				continue
+			}
+		}
+		if class == 0 {
+			starter = len(out)
+		}
+		last = class
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// Hangul syllables decompose by arithmetic rather than by table.
+const (
+	hangulBase  = 0xAC00
+	hangulL     = 0x1100
+	hangulV     = 0x1161
+	hangulT     = 0x11A7
+	hangulVs    = 21
+	hangulTs    = 28
+	hangulCount = 19 * hangulVs * hangulTs
+)
+
+func decompose(b []rune, r rune) []rune {
+	if r >= hangulBase && r < hangulBase+hangulCount {
+		i := r - hangulBase
+		b = append(b, hangulL+i/(hangulVs*hangulTs), hangulV+i%(hangulVs*hangulTs)/hangulTs)
+		if t := i % hangulTs; t != 0 {
+			b = append(b, hangulT+t)
+		}
+		return b
+	}
+	d, ok := decompositions[r]
+	if !ok {
+		return append(b, r)
+	}
+	b = decompose(b, d[0])
+	if d[1] != 0 {
+		b = decompose(b, d[1])
+	}
+	return b
+}
+
+var compositions = func() map[[2]rune]rune {
+	m := make(map[[2]rune]rune, len(decompositions))
+	for r, d := range decompositions {
+		// Singletons and decompositions starting with a mark never compose.
+		if d[1] != 0 && combiningClass(d[0]) == 0 {
+			m[d] = r
+		}
+	}
+	return m
+}()
+
+func compose(a, b rune) (rune, bool) {
+	switch {
+	case a >= hangulL && a < hangulL+19 && b >= hangulV && b < hangulV+hangulVs:
+		return hangulBase + ((a-hangulL)*hangulVs+b-hangulV)*hangulTs, true
+	case a >= hangulBase && a < hangulBase+hangulCount && (a-hangulBase)%hangulTs == 0 &&
+		b > hangulT && b < hangulT+hangulTs:
+		return a + b - hangulT, true
+	}
+	c, ok := compositions[[2]rune{a, b}]
+	return c, ok
+}
+
+func combiningClass(r rune) uint8 {
+	i := sort.Search(len(combiningClasses), func(i int) bool {
+		return combiningClasses[i].hi >= r
+	})
+	if i < len(combiningClasses) && combiningClasses[i].lo <= r {
+		return combiningClasses[i].ccc
+	}
+	return 0
+}
+
+// StripDiacritics removes combining marks, turning "é" into "e" and "й"
+// into "и". The result is in NFC.
+func StripDiacritics(s string) string {
+	d := NFD(s)
+	var b strings.Builder
+	b.Grow(len(d))
+	for _, r := range d {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return NFC(b.String())
+}
@@ -0,0 +1,56 @@
+package utils
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		nfd, nfc string
+	}{
+		{"cyrillic й", "й", "и\u0306", "й"},
+		{"decomposed й", "и\u0306", "и\u0306", "й"},
+		{"ё", "ёлка", "е\u0308лка", "ёлка"},
+		{"latin", "café", "cafe\u0301", "café"},
+		{"two marks", "ṩ", "s\u0323\u0307", "ṩ"},
+		{"mark order", "s\u0307\u0323", "s\u0323\u0307", "ṩ"},
+		{"no precomposed form", "q\u0307\u0323", "q\u0323\u0307", "q\u0323\u0307"},
+		{"hangul", "안녕", "\u110b\u1161\u11ab\u1102\u1167\u11bc", "안녕"},
+		{"tajik", "ӣӯ", "и\u0304у\u0304", "ӣӯ"},
+		{"greek", "ΐ", "ι\u0308\u0301", "ΐ"},
+		{"blocked mark", "e\u0323\u0301\u0302", "e\u0323\u0301\u0302", "ẹ\u0301\u0302"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NFD(tt.input); result != tt.nfd {
+				t.Errorf("NFD(%q) = %q; expected %q", tt.input, result, tt.nfd)
+			}
+			if result := NFC(tt.input); result != tt.nfc {
+				t.Errorf("NFC(%q) = %q; expected %q", tt.input, result, tt.nfc)
+			}
+			if result := Normalize(tt.input, FormNone); result != tt.input {
+				t.Errorf("Normalize(%q, FormNone) = %q; expected it unchanged", tt.input, result)
+			}
+		})
+	}
+}
+
+func TestStripDiacritics(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"café", "cafe"},
+		{"ёжик", "ежик"},
+		{"и\u0306од", "иод"},
+		{"Ṩ", "S"},
+		{"привет", "привет"},
+	}
+
+	for _, tt := range tests {
+		if result := StripDiacritics(tt.input); result != tt.expected {
+			t.Errorf("StripDiacritics(%q) = %q; expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PalindromeOptions configures IsPalindromeWith. The zero value compares
+// grapheme clusters case-insensitively and ignores nothing.
+type PalindromeOptions struct {
+	// Form normalizes the text first, so that precomposed and decomposed
+	// spellings of a letter compare equal.
+	Form Form
+	// IgnorePunctuation drops Unicode punctuation such as "!", "—" and "«".
+	IgnorePunctuation bool
+	// IgnoreSpace drops all Unicode white space, not just ASCII spaces.
+	IgnoreSpace bool
+	// FoldYo reads ё as е, as Russian text often does.
+	FoldYo bool
+	// StripDiacritics drops combining marks, so "é" matches "e".
+	StripDiacritics bool
+	// CaseSensitive keeps upper and lower case distinct.
+	CaseSensitive bool
+}
+
+var yoFolder = strings.NewReplacer("\u0451", "\u0435", "\u0401", "\u0415", "\u0435\u0308", "\u0435", "\u0415\u0308", "\u0415")
+
+// IsPalindromeWith reports whether s reads the same backwards after the
+// transformations selected by opts. Text is compared by grapheme clusters,
+// so letters with combining marks count as one character.
+func IsPalindromeWith(s string, opts PalindromeOptions) bool {
+	return mirrored(palindromeClusters(s, opts))
+}
+
+func mirrored(clusters []string) bool {
+	for i, j := 0, len(clusters)-1; i < j; i, j = i+1, j-1 {
+		if clusters[i] != clusters[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// palindromeClusters returns the grapheme clusters of s that
+// IsPalindromeWith compares.
+func palindromeClusters(s string, opts PalindromeOptions) []string {
+	if opts.StripDiacritics {
+		s = StripDiacritics(s)
+	}
+	if opts.FoldYo {
+		s = yoFolder.Replace(s)
+	}
+	s = Normalize(s, opts.Form)
+	if !opts.CaseSensitive {
+		s = strings.ToLower(s)
+	}
+	s = strings.Map(func(r rune) rune {
+		if opts.IgnoreSpace && unicode.IsSpace(r) || opts.IgnorePunctuation && unicode.IsPunct(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	return Graphemes(s)
+}
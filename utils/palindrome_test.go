@@ -0,0 +1,41 @@
+package utils
+
+import "testing"
+
+func TestIsPalindromeWith(t *testing.T) {
+	loose := PalindromeOptions{Form: FormNFC, IgnorePunctuation: true, IgnoreSpace: true, FoldYo: true}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     PalindromeOptions
+		expected bool
+	}{
+		{"empty string", "", PalindromeOptions{}, true},
+		{"plain", "казак", PalindromeOptions{}, true},
+		{"spaces count by default", "а роза упала на лапу азора", PalindromeOptions{}, false},
+		{"punctuation", "А роза упала на лапу Азора!", loose, true},
+		{"tabs and no-break spaces", "А\tроза\u00a0упала на\u2003лапу Азора", loose, true},
+		{"dashes and quotes", "«Аргентина — манит негра»", loose, true},
+		{"yo folded", "Ёж ел, лежё", loose, true},
+		{"yo kept", "\u0451же", PalindromeOptions{}, false},
+		{"decomposed yo folded", "е\u0308же", loose, true},
+		{"precomposed against decomposed", "\u0439и\u0306", PalindromeOptions{Form: FormNFC}, true},
+		{"decomposed form", "\u0439и\u0306", PalindromeOptions{Form: FormNFD}, true},
+		{"forms differ without normalization", "\u0439и\u0306", PalindromeOptions{}, false},
+		{"combining mark stays on its letter", "и\u0306и", PalindromeOptions{}, false},
+		{"diacritics", "Ésope reste ici et se repose", PalindromeOptions{IgnoreSpace: true, StripDiacritics: true}, true},
+		{"case sensitive", "Racecar", PalindromeOptions{CaseSensitive: true}, false},
+		{"not palindrome", "привет, мир", loose, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsPalindromeWith(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("IsPalindromeWith(%q, %+v) = %t; expected %t",
+					tt.input, tt.opts, result, tt.expected)
+			}
+		})
+	}
+}
@@ -10,8 +10,9 @@ func Reverse(s string) string {
 	return string(runes)
 }
 
+const vowels = "aeiouаеёиоуыэюяAEIOUАЕЁИОУЫЭЮЯ"
+
 func CountVowels(s string) int {
-	vowels := "aeiouаеёиоуыэюяAEIOUАЕЁИОУЫЭЮЯ"
 	count := 0
 	for _, char := range s {
 		if strings.ContainsRune(vowels, char) {
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+var ErrLineTooLong = errors.New("line too long")
+
+// DefaultMaxLineBytes bounds the lines PalindromeScanner and ReverseWriter
+// hold in memory unless told otherwise.
+const DefaultMaxLineBytes = bufio.MaxScanTokenSize
+
+// CountVowelsReader is CountVowels over a stream. It reads rune by rune
+// through a small buffer, so input of any size takes constant memory and
+// multi-byte runes split between reads are decoded whole.
+func CountVowelsReader(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	count := 0
+	for {
+		char, _, err := br.ReadRune()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		if strings.ContainsRune(vowels, char) {
+			count++
+		}
+	}
+}
+
+// PalindromeScanner reads lines from a stream and stops at those that are
+// palindromes under its options:
+//
+//	s := utils.NewPalindromeScanner(f, opts)
+//	for s.Scan() {
+//		fmt.Println(s.LineNumber(), s.Line())
+//	}
+//	if err := s.Err(); err != nil { ... }
+//
+// Empty lines are skipped, as are lines such as "!!!" that the options
+// reduce to nothing. Lines longer than DefaultMaxLineBytes, or the limit
+// given to NewPalindromeScannerSize, stop the scan with ErrLineTooLong.
+type PalindromeScanner struct {
+	scanner *bufio.Scanner
+	opts    PalindromeOptions
+	line    string
+	lineNo  int
+	err     error
+}
+
+func NewPalindromeScanner(r io.Reader, opts PalindromeOptions) *PalindromeScanner {
+	return NewPalindromeScannerSize(r, opts, DefaultMaxLineBytes)
+}
+
+// NewPalindromeScannerSize is NewPalindromeScanner with a different limit on
+// the length of a line; zero or less means DefaultMaxLineBytes.
+func NewPalindromeScannerSize(r io.Reader, opts PalindromeOptions, maxLineBytes int) *PalindromeScanner {
+	if maxLineBytes <= 0 {
+		maxLineBytes = DefaultMaxLineBytes
+	}
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, min(4096, maxLineBytes)), maxLineBytes)
+	return &PalindromeScanner{scanner: s, opts: opts}
+}
+
+// Scan advances to the next palindromic line and reports whether there is
+// one.
+func (s *PalindromeScanner) Scan() bool {
+	for s.scanner.Scan() {
+		s.lineNo++
+		line := strings.TrimSuffix(s.scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if clusters := palindromeClusters(line, s.opts); len(clusters) > 0 && mirrored(clusters) {
+			s.line = line
+			return true
+		}
+	}
+	s.line = ""
+	s.err = s.scanner.Err()
+	if errors.Is(s.err, bufio.ErrTooLong) {
+		s.err = ErrLineTooLong
+	}
+	return false
+}
+
+// Line returns the palindrome found by the last call to Scan, without its
+// line ending.
+func (s *PalindromeScanner) Line() string { return s.line }
+
+// LineNumber returns the 1-based number of that line in the input.
+func (s *PalindromeScanner) LineNumber() int { return s.lineNo }
+
+func (s *PalindromeScanner) Err() error { return s.err }
+
+// ReverseWriter writes every line written to it to the underlying writer
+// with its grapheme clusters reversed, keeping the line ending in place.
+// Lines are buffered until their newline arrives, so a rune or cluster may
+// be split across any number of Write calls. Flush writes a final line
+// that has no newline.
+type ReverseWriter struct {
+	// MaxLineBytes limits the buffered line; zero means DefaultMaxLineBytes.
+	MaxLineBytes int
+
+	w    io.Writer
+	line []byte
+}
+
+func NewReverseWriter(w io.Writer) *ReverseWriter {
+	return &ReverseWriter{w: w}
+}
+
+func (rw *ReverseWriter) Write(p []byte) (int, error) {
+	maxLine := rw.MaxLineBytes
+	if maxLine <= 0 {
+		maxLine = DefaultMaxLineBytes
+	}
+	written := 0
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		chunk := p
+		if i >= 0 {
+			chunk = p[:i]
+		}
+		if len(rw.line)+len(chunk) > maxLine {
+			return written, ErrLineTooLong
+		}
+		rw.line = append(rw.line, chunk...)
+		if i < 0 {
+			return written + len(p), nil
+		}
+		if err := rw.emit("\n"); err != nil {
+			return written, err
+		}
+		written += i + 1
+		p = p[i+1:]
+	}
+	return written, nil
+}
+
+// Flush writes the buffered partial line, if any.
+func (rw *ReverseWriter) Flush() error {
+	if len(rw.line) == 0 {
+		return nil
+	}
+	return rw.emit("")
+}
+
+func (rw *ReverseWriter) emit(eol string) error {
+	line := string(rw.line)
+	if strings.HasSuffix(line, "\r") {
+		line, eol = line[:len(line)-1], "\r"+eol
+	}
+	rw.line = rw.line[:0]
+	_, err := io.WriteString(rw.w, ReverseGraphemes(line)+eol)
+	return err
+}
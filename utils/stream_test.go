@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCountVowelsReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"empty", "", 0},
+		{"english vowels", "hello world", 3},
+		{"russian vowels", "привет мир", 3},
+		{"large input", strings.Repeat("абв xyz e\n", 10000), 20000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// One byte per read splits every Cyrillic rune across reads.
+			result, err := CountVowelsReader(iotest.OneByteReader(strings.NewReader(tt.input)))
+			if err != nil || result != tt.expected || result != CountVowels(tt.input) {
+				t.Errorf("CountVowelsReader(%q) = %d, %v; expected %d", tt.name, result, err, tt.expected)
+			}
+		})
+	}
+
+	if _, err := CountVowelsReader(iotest.ErrReader(errors.New("boom"))); err == nil {
+		t.Errorf("CountVowelsReader did not report the read error")
+	}
+}
+
+func TestPalindromeScanner(t *testing.T) {
+	input := "казак\r\nhello\n\nА роза упала на лапу Азора!\n!!! ?\nшалаш"
+	s := NewPalindromeScanner(iotest.HalfReader(strings.NewReader(input)),
+		PalindromeOptions{IgnorePunctuation: true, IgnoreSpace: true})
+
+	var lines []string
+	var numbers []int
+	for s.Scan() {
+		lines = append(lines, s.Line())
+		numbers = append(numbers, s.LineNumber())
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	expected := []string{"казак", "А роза упала на лапу Азора!", "шалаш"}
+	if strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("lines = %q; expected %q", lines, expected)
+	}
+	if len(numbers) != 3 || numbers[0] != 1 || numbers[1] != 4 || numbers[2] != 6 {
+		t.Errorf("line numbers = %v; expected [1 4 6]", numbers)
+	}
+
+	long := NewPalindromeScannerSize(strings.NewReader(strings.Repeat("a", 100)+"\n"), PalindromeOptions{}, 16)
+	if long.Scan() || !errors.Is(long.Err(), ErrLineTooLong) {
+		t.Errorf("long line: Err() = %v; expected %v", long.Err(), ErrLineTooLong)
+	}
+
+	for _, size := range []int{0, -1} {
+		s := NewPalindromeScannerSize(strings.NewReader("abba\n"), PalindromeOptions{}, size)
+		if !s.Scan() || s.Line() != "abba" {
+			t.Errorf("size %d: Scan() = false, Err() = %v; expected the default limit", size, s.Err())
+		}
+	}
+}
+
+func TestReverseWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lines", "abc\nпривет\n", "cba\nтевирп\n"},
+		{"crlf", "abc\r\nde\r\n", "cba\r\ned\r\n"},
+		{"no final newline", "abc\nxy", "cba\nyx"},
+		{"graphemes", "йод 👍\U0001f3fd\n", "👍\U0001f3fd дой\n"},
+		{"empty lines", "\n\n", "\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			w := NewReverseWriter(&out)
+			// Write one byte at a time to split runes and clusters.
+			for i := 0; i < len(tt.input); i++ {
+				if n, err := w.Write([]byte{tt.input[i]}); n != 1 || err != nil {
+					t.Fatalf("Write = %d, %v", n, err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush error: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("output = %q; expected %q", out.String(), tt.expected)
+			}
+		})
+	}
+
+	w := NewReverseWriter(&strings.Builder{})
+	w.MaxLineBytes = 4
+	if _, err := w.Write([]byte("abc\nabcdef\n")); !errors.Is(err, ErrLineTooLong) {
+		t.Errorf("Write long line error = %v; expected %v", err, ErrLineTooLong)
+	}
+}
@@ -0,0 +1,220 @@
+// Package translit transliterates Russian and Tajik text between Cyrillic
+// and Latin script. Three schemes are provided:
+//
+//	ISO9   ISO 9:1995 (also GOST 7.79-2000 System A): one Latin letter,
+//	       possibly with diacritics, per Cyrillic letter: Щ → Ŝ, Ҷ → Ç
+//	GOST   GOST 7.79-2000 System B: ASCII only, using digraphs and
+//	       backticks: Щ → Shh, Ы → Y`, Ҷ → Ch`
+//	BGN    BGN/PCGN, the usual spelling in English texts: Щ → Shch,
+//	       Ё → Yë, Ҷ → J
+//
+// The Tajik letters Ғ, Ӣ, Қ, Ӯ, Ҳ and Ҷ are handled in every scheme. ISO9
+// and GOST can be reversed with ToCyrillic; BGN loses information (Й and Ы
+// are both y) and cannot.
+package translit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang-lessons/utils"
+)
+
+var ErrIrreversible = errors.New("scheme is not reversible")
+
+// Scheme is a transliteration table from lower-case Cyrillic letters to
+// Latin. contextual, if set, overrides the table for letters whose
+// spelling depends on their neighbours; it receives lower-case runes and 0
+// at the edges of the text.
+type Scheme struct {
+	Name string
+
+	letters    map[rune]string
+	contextual func(prev, r, next rune) (string, bool)
+	// aliases are additional Latin spellings accepted by ToCyrillic.
+	aliases    map[string]rune
+	reversible bool
+}
+
+var (
+	ISO9 = &Scheme{
+		Name: "ISO 9",
+		letters: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "ë",
+			'ж': "ž", 'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m",
+			'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+			'ф': "f", 'х': "h", 'ц': "c", 'ч': "č", 'ш': "š", 'щ': "ŝ", 'ъ': "ʺ",
+			'ы': "y", 'ь': "ʹ", 'э': "è", 'ю': "û", 'я': "â",
+			'ғ': "ǧ", 'ӣ': "ī", 'қ': "ķ", 'ӯ': "ū", 'ҳ': "ḩ", 'ҷ': "ç",
+		},
+		reversible: true,
+	}
+
+	GOST = &Scheme{
+		Name: "GOST 7.79 System B",
+		letters: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+			'ж': "zh", 'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m",
+			'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+			'ф': "f", 'х': "x", 'ц': "cz", 'ч': "ch", 'ш': "sh", 'щ': "shh", 'ъ': "``",
+			'ы': "y`", 'ь': "`", 'э': "e`", 'ю': "yu", 'я': "ya",
+			'ғ': "g`", 'ӣ': "i`", 'қ': "k`", 'ӯ': "u`", 'ҳ': "h`", 'ҷ': "ch`",
+		},
+		// Ц is c before е, и, ы and й, whose spellings start with e, i, y and j.
+		contextual: func(prev, r, next rune) (string, bool) {
+			if r == 'ц' && strings.ContainsRune("еиыйӣ", next) {
+				return "c", true
+			}
+			return "", false
+		},
+		aliases:    map[string]rune{"c": 'ц'},
+		reversible: true,
+	}
+
+	BGN = &Scheme{
+		Name: "BGN/PCGN",
+		letters: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "ë",
+			'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+			'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+			'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "ʺ",
+			'ы': "y", 'ь': "ʹ", 'э': "e", 'ю': "yu", 'я': "ya",
+			'ғ': "gh", 'ӣ': "ī", 'қ': "q", 'ӯ': "ū", 'ҳ': "ḩ", 'ҷ': "j",
+		},
+		// Е and Ё are ye and yë at the start of a word and after a vowel,
+		// й, ъ or ь.
+		contextual: func(prev, r, next rune) (string, bool) {
+			if (r == 'е' || r == 'ё') && (!unicode.IsLetter(prev) || strings.ContainsRune("аеёиоуыэюяӣӯйъь", prev)) {
+				if r == 'е' {
+					return "ye", true
+				}
+				return "yë", true
+			}
+			return "", false
+		},
+	}
+)
+
+func (s *Scheme) String() string { return s.Name }
+
+// ToLatin transliterates the Cyrillic letters of text and leaves everything
+// else as it is. An upper-case letter that becomes several Latin letters is
+// written in capitals inside an upper-case word ("ЩИ" → "SHHI") and
+// capitalized otherwise ("Щи" → "Shhi").
+func ToLatin(text string, scheme *Scheme) string {
+	runes := []rune(utils.NFC(text))
+	var b strings.Builder
+	b.Grow(len(text))
+	for i, r := range runes {
+		lower := unicode.ToLower(r)
+		var prev, next rune
+		if i > 0 {
+			prev = runes[i-1]
+		}
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		latin, ok := "", false
+		if scheme.contextual != nil {
+			latin, ok = scheme.contextual(unicode.ToLower(prev), lower, unicode.ToLower(next))
+		}
+		if !ok {
+			latin, ok = scheme.letters[lower]
+		}
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if r != lower {
+			if unicode.IsUpper(next) || !unicode.IsLetter(next) && unicode.IsUpper(prev) {
+				latin = strings.ToUpper(latin)
+			} else {
+				first, size := utf8.DecodeRuneInString(latin)
+				latin = string(unicode.ToUpper(first)) + latin[size:]
+			}
+		}
+		b.WriteString(latin)
+	}
+	return b.String()
+}
+
+// ToCyrillic reverses ToLatin for the letters of lang, which decides
+// between spellings that are ambiguous across languages: in GOST, ch` is
+// Tajik ҷ but Russian чь. A nil lang means utils.Russian. Latin text that
+// no letter of lang is spelled with is left as it is.
+func ToCyrillic(text string, scheme *Scheme, lang *utils.Language) (string, error) {
+	if !scheme.reversible {
+		return "", fmt.Errorf("%w: %s", ErrIrreversible, scheme.Name)
+	}
+	if lang == nil {
+		lang = utils.Russian
+	}
+	table, longest := reverseTable(scheme, lang)
+
+	runes := []rune(utils.NFC(text))
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(runes); {
+		matched := false
+		for n := min(longest, len(runes)-i); n > 0; n-- {
+			c, ok := table[strings.ToLower(string(runes[i:i+n]))]
+			if !ok {
+				continue
+			}
+			if upper(runes, i, n) {
+				c = unicode.ToUpper(c)
+			}
+			b.WriteRune(c)
+			i += n
+			matched = true
+			break
+		}
+		if !matched {
+			b.WriteRune(runes[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// upper reports whether the n runes at runes[i] spell an upper-case letter.
+// Spellings without case, such as ʹ for ь, take it from the surrounding
+// word the way ToLatin writes them.
+func upper(runes []rune, i, n int) bool {
+	if first := runes[i]; unicode.IsUpper(first) || unicode.IsLower(first) {
+		return unicode.IsUpper(first)
+	}
+	var prev, next rune
+	if i > 0 {
+		prev = runes[i-1]
+	}
+	if i+n < len(runes) {
+		next = runes[i+n]
+	}
+	return unicode.IsUpper(prev) && (unicode.IsUpper(next) || !unicode.IsLetter(next))
+}
+
+// reverseTable maps the Latin spellings of the letters of lang back to
+// Cyrillic and returns the length in runes of the longest spelling.
+func reverseTable(scheme *Scheme, lang *utils.Language) (map[string]rune, int) {
+	alphabet := lang.Vowels + lang.Consonants + lang.Signs
+	table := make(map[string]rune)
+	longest := 0
+	add := func(latin string, r rune) {
+		if strings.ContainsRune(alphabet, r) {
+			table[latin] = r
+			longest = max(longest, utf8.RuneCountInString(latin))
+		}
+	}
+	for r, latin := range scheme.letters {
+		add(latin, r)
+	}
+	for latin, r := range scheme.aliases {
+		add(latin, r)
+	}
+	return table, longest
+}
@@ -0,0 +1,112 @@
+package translit
+
+import (
+	"errors"
+	"testing"
+
+	"golang-lessons/utils"
+)
+
+func TestToLatin(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		scheme   *Scheme
+		expected string
+	}{
+		{"iso9 name", "Алексей", ISO9, "Aleksej"},
+		{"gost name", "Алексей", GOST, "Aleksej"},
+		{"bgn name", "Алексей", BGN, "Aleksey"},
+		{"iso9 city", "Город: Душанбе", ISO9, "Gorod: Dušanbe"},
+		{"gost city", "Город: Душанбе", GOST, "Gorod: Dushanbe"},
+		{"bgn city", "Город: Душанбе", BGN, "Gorod: Dushanbe"},
+		{"iso9 signs", "Съешь щи", ISO9, "Sʺešʹ ŝi"},
+		{"gost signs", "Съешь щи", GOST, "S``esh` shhi"},
+		{"bgn signs", "Съешь щи", BGN, "Sʺyeshʹ shchi"},
+		{"gost ц before i", "цирк", GOST, "cirk"},
+		{"gost ц elsewhere", "царь", GOST, "czar`"},
+		{"gost ы э", "быть эхом", GOST, "by`t` e`xom"},
+		{"bgn е at start", "Ель", BGN, "Yelʹ"},
+		{"bgn ё after vowel", "моё", BGN, "moyë"},
+		{"bgn ё after consonant", "тёма", BGN, "tëma"},
+		{"title case", "Жанна", GOST, "Zhanna"},
+		{"all caps", "ЖУК", GOST, "ZHUK"},
+		{"all caps last letter", "ЁРШ", BGN, "YËRSH"},
+		{"single capital", "Щ", GOST, "Shh"},
+		{"iso9 tajik", "ғӣқӯҳҷ", ISO9, "ǧīķūḩç"},
+		{"gost tajik", "Ҷӯра Қодирӣ", GOST, "Ch`u`ra K`odiri`"},
+		{"bgn tajik", "Ҳисор, Ғарм, Ҷиргатол", BGN, "Ḩisor, Gharm, Jirgatol"},
+		{"decomposed input", "и\u0306од", ISO9, "jod"},
+		{"non-cyrillic kept", "Hello, мир!", ISO9, "Hello, mir!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ToLatin(tt.input, tt.scheme); result != tt.expected {
+				t.Errorf("ToLatin(%q, %s) = %q; expected %q", tt.input, tt.scheme, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToCyrillic(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		scheme   *Scheme
+		lang     *utils.Language
+		expected string
+	}{
+		{"iso9", "Dušanbe", ISO9, nil, "Душанбе"},
+		{"gost", "Shhuka", GOST, nil, "Щука"},
+		{"gost all caps", "SHHUKA", GOST, nil, "ЩУКА"},
+		{"gost ц as c", "cirk", GOST, nil, "цирк"},
+		{"gost ch` in russian", "noch`", GOST, utils.Russian, "ночь"},
+		{"gost ch` in tajik", "ch`u`ra", GOST, utils.Tajik, "ҷӯра"},
+		{"decomposed latin", "Dus\u030canbe", ISO9, nil, "Душанбе"},
+		{"unknown latin kept", "qwh", GOST, nil, "qwh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToCyrillic(tt.input, tt.scheme, tt.lang)
+			if err != nil {
+				t.Fatalf("ToCyrillic(%q, %s) returned error: %v", tt.input, tt.scheme, err)
+			}
+			if result != tt.expected {
+				t.Errorf("ToCyrillic(%q, %s) = %q; expected %q", tt.input, tt.scheme, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		input string
+		lang  *utils.Language
+	}{
+		{"Имя: Самир, город: Душанбе", utils.Russian},
+		{"Съешь же ещё этих мягких французских булок, да выпей чаю", utils.Russian},
+		{"ЦАРЬ ПОДЪЕХАЛ К ЦИРКУ", utils.Russian},
+		{"Ҷӯра Қодирӣ аз Ҳисор ба Ғарм рафт", utils.Tajik},
+	}
+
+	for _, scheme := range []*Scheme{ISO9, GOST} {
+		for _, tt := range tests {
+			latin := ToLatin(tt.input, scheme)
+			result, err := ToCyrillic(latin, scheme, tt.lang)
+			if err != nil {
+				t.Fatalf("ToCyrillic(%q, %s) returned error: %v", latin, scheme, err)
+			}
+			if result != tt.input {
+				t.Errorf("%s: %q → %q → %q", scheme, tt.input, latin, result)
+			}
+		}
+	}
+}
+
+func TestToCyrillicIrreversible(t *testing.T) {
+	if _, err := ToCyrillic("Aleksey", BGN, nil); !errors.Is(err, ErrIrreversible) {
+		t.Errorf("ToCyrillic with BGN returned %v; expected ErrIrreversible", err)
+	}
+}